@@ -62,6 +62,11 @@ func main() {
 	// Initialize queue client
 	queueClient := queue.NewClient(&cfg.Redis)
 
+	// Executions are staged per workspace and fed to the workers fairly
+	if cfg.Features.FairQueue.Enabled {
+		queueClient.SetFairQueue(queue.NewFairQueue(redisClient.Client, queueClient))
+	}
+
 	// Initialize repositories
 	userRepo := repositories.NewUserRepository(db)
 	sessionRepo := repositories.NewSessionRepository(db)
@@ -44,6 +44,11 @@ func main() {
 	// Initialize queue client
 	queueClient := queue.NewClient(&cfg.Redis)
 
+	// Executions are staged per workspace and fed to the workers fairly
+	if cfg.Features.FairQueue.Enabled {
+		queueClient.SetFairQueue(queue.NewFairQueue(redisClient.Client, queueClient))
+	}
+
 	// Create scheduler config
 	schedulerCfg := scheduler.DefaultConfig()
 
@@ -9,6 +9,7 @@ import (
 	"syscall"
 
 	"github.com/hibiken/asynq"
+	"github.com/linkflow-ai/linkflow/internal/domain/models"
 	"github.com/linkflow-ai/linkflow/internal/domain/repositories"
 	"github.com/linkflow-ai/linkflow/internal/domain/services"
 	"github.com/linkflow-ai/linkflow/internal/pkg/config"
@@ -75,6 +76,9 @@ func main() {
 	subscriptionRepo := repositories.NewSubscriptionRepository(db)
 	usageRepo := repositories.NewUsageRepository(db)
 	invoiceRepo := repositories.NewInvoiceRepository(db)
+	pinnedDataRepo := repositories.NewPinnedDataRepository(db)
+	waitingExecRepo := repositories.NewWaitingExecutionRepository(db)
+	envVarRepo := repositories.NewBaseRepository[models.EnvironmentVariable](db)
 
 	// Initialize crypto
 	encryptor, err := crypto.NewEncryptor(cfg.JWT.Secret[:32])
@@ -84,9 +88,11 @@ func main() {
 
 	// Initialize services
 	workflowSvc := services.NewWorkflowService(workflowRepo, versionRepo)
+	workflowSvc.SetPinnedDataRepo(pinnedDataRepo) // Pinned data for manual/test runs
 	executionSvc := services.NewExecutionService(executionRepo, nodeExecutionRepo, workflowRepo)
 	credentialSvc := services.NewCredentialService(credentialRepo, encryptor)
 	billingSvc := services.NewBillingService(planRepo, subscriptionRepo, usageRepo, invoiceRepo, workspaceRepo)
+	envVarSvc := services.NewEnvironmentVariableService(envVarRepo, encryptor)
 
 	// Initialize email service
 	emailCfg := &email.Config{
@@ -103,6 +109,9 @@ func main() {
 	// Initialize queue client for webhook consumer
 	queueClient := queue.NewClient(&cfg.Redis)
 	defer queueClient.Close()
+	if cfg.Features.FairQueue.Enabled {
+		queueClient.SetFairQueue(queue.NewFairQueue(redisClient.Client, queueClient))
+	}
 
 	// Initialize webhook stream consumers if enabled
 	var webhookConsumers []*streams.WebhookConsumer
@@ -119,7 +128,7 @@ func main() {
 
 		for i := 0; i < consumerCount; i++ {
 			consumerName := fmt.Sprintf("worker-%d-consumer-%d", os.Getpid(), i)
-			consumer := streams.NewWebhookConsumer(webhookStream, workflowSvc, queueClient, consumerName)
+			consumer := streams.NewWebhookConsumer(webhookStream, workflowSvc, executionSvc, queueClient, consumerName)
 
 			if err := consumer.Start(ctx); err != nil {
 				log.Error().Err(err).Int("consumer", i).Msg("Failed to start webhook consumer")
@@ -136,6 +145,19 @@ func main() {
 	// Create worker
 	w := worker.New(cfg, executionSvc, credentialSvc, workflowSvc, billingSvc, redisClient.Client, emailSvc)
 
+	// Durable waits suspend executions to the database
+	baseURL := cfg.App.URL
+	if baseURL == "" {
+		baseURL = fmt.Sprintf("http://localhost:%d", cfg.Server.Port)
+	}
+	w.GetExecutor().SetWaitResumeManager(services.NewWaitResumeManager(waitingExecRepo, baseURL))
+
+	// Saved execution state holds raw node outputs, so it's stored encrypted
+	w.SetStateEncryptor(encryptor)
+
+	// Workspace environment variables are available to expressions as $env
+	w.GetExecutor().SetEnvironmentVariables(envVarSvc, cfg.App.Environment)
+
 	// Handle shutdown
 	go func() {
 		quit := make(chan os.Signal, 1)
@@ -41,6 +41,8 @@ require (
 	github.com/expr-lang/expr v1.17.7
 	github.com/go-sql-driver/mysql v1.9.3
 	github.com/jlaffaye/ftp v0.2.0
+	github.com/jmespath/go-jmespath v0.4.0
+	github.com/oliveagle/jsonpath v0.0.0-20180606110733-2e52cf6e6852
 	github.com/prometheus/client_golang v1.23.2
 	go.mongodb.org/mongo-driver v1.17.6
 	golang.org/x/time v0.5.0
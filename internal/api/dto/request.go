@@ -89,6 +89,8 @@ type UpdateWorkflowRequest struct {
 
 type ExecuteWorkflowRequest struct {
 	InputData models.JSON `json:"input_data,omitempty"`
+	Test      bool        `json:"test,omitempty"`                                       // Test run: pinned node data is used
+	Priority  int         `json:"priority,omitempty" validate:"omitempty,min=1,max=10"` // Defaults to interactive priority
 }
 
 type CloneWorkflowRequest struct {
@@ -117,6 +119,7 @@ type CreateScheduleRequest struct {
 	CronExpression string      `json:"cron_expression" validate:"required,cron"`
 	Timezone       string      `json:"timezone" validate:"required"`
 	InputData      models.JSON `json:"input_data,omitempty"`
+	Priority       int         `json:"priority,omitempty" validate:"omitempty,min=1,max=10"`
 }
 
 type UpdateScheduleRequest struct {
@@ -125,6 +128,7 @@ type UpdateScheduleRequest struct {
 	CronExpression *string     `json:"cron_expression,omitempty" validate:"omitempty,cron"`
 	Timezone       *string     `json:"timezone,omitempty"`
 	InputData      models.JSON `json:"input_data,omitempty"`
+	Priority       *int        `json:"priority,omitempty" validate:"omitempty,min=1,max=10"`
 }
 
 // Billing
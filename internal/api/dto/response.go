@@ -355,6 +355,7 @@ type ExecutionResponse struct {
 	OutputData      interface{} `json:"output_data,omitempty"`
 	ErrorMessage    *string     `json:"error_message,omitempty"`
 	ErrorNodeID     *string     `json:"error_node_id,omitempty"`
+	ErrorDetails    interface{} `json:"error_details,omitempty"`
 	NodesTotal      int         `json:"nodes_total"`
 	NodesCompleted  int         `json:"nodes_completed"`
 	QueuedAt        int64       `json:"queued_at"`
@@ -371,7 +372,11 @@ type NodeExecutionResponse struct {
 	InputData    interface{} `json:"input_data,omitempty"`
 	OutputData   interface{} `json:"output_data,omitempty"`
 	ErrorMessage *string     `json:"error_message,omitempty"`
+	ErrorDetails interface{} `json:"error_details,omitempty"`
 	DurationMs   *int        `json:"duration_ms,omitempty"`
+	RetryCount   int         `json:"retry_count"`
+	Cached       bool        `json:"cached"`
+	Pinned       bool        `json:"pinned"`
 	StartedAt    *int64      `json:"started_at,omitempty"`
 	CompletedAt  *int64      `json:"completed_at,omitempty"`
 }
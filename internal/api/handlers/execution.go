@@ -10,15 +10,18 @@ import (
 	"github.com/google/uuid"
 	"github.com/linkflow-ai/linkflow/internal/api/dto"
 	"github.com/linkflow-ai/linkflow/internal/api/middleware"
+	"github.com/linkflow-ai/linkflow/internal/domain/models"
 	"github.com/linkflow-ai/linkflow/internal/domain/repositories"
 	"github.com/linkflow-ai/linkflow/internal/domain/services"
 	"github.com/linkflow-ai/linkflow/internal/pkg/queue"
 	"github.com/linkflow-ai/linkflow/internal/pkg/validator"
+	"github.com/linkflow-ai/linkflow/internal/worker/processor"
 )
 
 type ExecutionHandler struct {
 	executionSvc *services.ExecutionService
 	queueClient  *queue.Client
+	cancellation *processor.CancellationManager
 }
 
 func NewExecutionHandler(executionSvc *services.ExecutionService, queueClient *queue.Client) *ExecutionHandler {
@@ -28,6 +31,12 @@ func NewExecutionHandler(executionSvc *services.ExecutionService, queueClient *q
 	}
 }
 
+// SetCancellationManager enables pausing running executions, by signalling
+// the worker running them
+func (h *ExecutionHandler) SetCancellationManager(cancellation *processor.CancellationManager) {
+	h.cancellation = cancellation
+}
+
 func (h *ExecutionHandler) List(w http.ResponseWriter, r *http.Request) {
 	wsCtx := middleware.GetWorkspaceFromContext(r.Context())
 	if wsCtx == nil {
@@ -67,6 +76,7 @@ func (h *ExecutionHandler) List(w http.ResponseWriter, r *http.Request) {
 			OutputData:      exec.OutputData,
 			ErrorMessage:    exec.ErrorMessage,
 			ErrorNodeID:     exec.ErrorNodeID,
+			ErrorDetails:    errorDetails(exec.ErrorDetails),
 			NodesTotal:      exec.NodesTotal,
 			NodesCompleted:  exec.NodesCompleted,
 			QueuedAt:        exec.QueuedAt.Unix(),
@@ -127,6 +137,7 @@ func (h *ExecutionHandler) Get(w http.ResponseWriter, r *http.Request) {
 		OutputData:      execution.OutputData,
 		ErrorMessage:    execution.ErrorMessage,
 		ErrorNodeID:     execution.ErrorNodeID,
+		ErrorDetails:    errorDetails(execution.ErrorDetails),
 		NodesTotal:      execution.NodesTotal,
 		NodesCompleted:  execution.NodesCompleted,
 		QueuedAt:        execution.QueuedAt.Unix(),
@@ -180,7 +191,11 @@ func (h *ExecutionHandler) GetNodes(w http.ResponseWriter, r *http.Request) {
 			InputData:    ne.InputData,
 			OutputData:   ne.OutputData,
 			ErrorMessage: ne.ErrorMessage,
+			ErrorDetails: errorDetails(ne.ErrorDetails),
 			DurationMs:   ne.DurationMs,
+			RetryCount:   ne.RetryCount,
+			Cached:       ne.Cached,
+			Pinned:       ne.Pinned,
 			StartedAt:    startedAt,
 			CompletedAt:  completedAt,
 		})
@@ -225,6 +240,119 @@ func (h *ExecutionHandler) Cancel(w http.ResponseWriter, r *http.Request) {
 	dto.OK(w, map[string]string{"status": "cancelled"})
 }
 
+// Pause asks the worker running an execution to pause it. The nodes running
+// finish and the execution becomes paused once its state is stored.
+func (h *ExecutionHandler) Pause(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.GetUserFromContext(r.Context())
+	if claims == nil {
+		dto.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	executionID, err := uuid.Parse(chi.URLParam(r, "executionID"))
+	if err != nil {
+		dto.BadRequest(w, "invalid execution ID")
+		return
+	}
+
+	// SECURITY: Validate ownership before pausing
+	existing, err := h.executionSvc.GetByID(r.Context(), executionID)
+	if err != nil {
+		dto.NotFound(w, "Execution")
+		return
+	}
+	if !ValidateWorkspaceOwnership(w, r, existing) {
+		return
+	}
+
+	if err := validator.CanPauseExecution(existing.Status); err != nil {
+		dto.BadRequest(w, err.Error())
+		return
+	}
+
+	if h.cancellation == nil {
+		dto.ServiceUnavailable(w, "pausing executions is not available")
+		return
+	}
+
+	if err := h.cancellation.Pause(r.Context(), executionID, claims.UserID.String()); err != nil {
+		dto.InternalServerError(w, "failed to pause execution")
+		return
+	}
+
+	dto.Accepted(w, map[string]string{
+		"execution_id": executionID.String(),
+		"status":       "pausing",
+	})
+}
+
+// Resume queues a paused execution to continue from the nodes it finished
+func (h *ExecutionHandler) Resume(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.GetUserFromContext(r.Context())
+	if claims == nil {
+		dto.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	executionID, err := uuid.Parse(chi.URLParam(r, "executionID"))
+	if err != nil {
+		dto.BadRequest(w, "invalid execution ID")
+		return
+	}
+
+	// SECURITY: Validate ownership before resuming
+	existing, err := h.executionSvc.GetByID(r.Context(), executionID)
+	if err != nil {
+		dto.NotFound(w, "Execution")
+		return
+	}
+	if !ValidateWorkspaceOwnership(w, r, existing) {
+		return
+	}
+
+	if err := validator.CanResumeExecution(existing.Status); err != nil {
+		dto.BadRequest(w, err.Error())
+		return
+	}
+
+	if h.cancellation != nil {
+		if err := h.cancellation.ClearPause(r.Context(), executionID); err != nil {
+			dto.InternalServerError(w, "failed to resume execution")
+			return
+		}
+	}
+
+	if err := h.executionSvc.QueueResume(r.Context(), executionID); err != nil {
+		if err == services.ErrExecutionNotPaused {
+			dto.BadRequest(w, "execution is not paused")
+			return
+		}
+		dto.InternalServerError(w, "failed to resume execution")
+		return
+	}
+
+	_, err = h.queueClient.EnqueueWorkflowExecution(r.Context(), queue.WorkflowExecutionPayload{
+		WorkflowID:  existing.WorkflowID,
+		WorkspaceID: existing.WorkspaceID,
+		ExecutionID: existing.ID,
+		TriggeredBy: existing.TriggeredBy,
+		TriggerType: existing.TriggerType,
+		TriggerData: existing.TriggerData,
+		InputData:   existing.InputData,
+		Priority:    existing.Priority,
+	})
+	if err != nil {
+		_ = h.executionSvc.UnqueueResume(r.Context(), executionID)
+		dto.InternalServerError(w, "failed to queue execution")
+		return
+	}
+
+	dto.Accepted(w, map[string]string{
+		"execution_id": executionID.String(),
+		"status":       "queued",
+	})
+}
+
 func (h *ExecutionHandler) Retry(w http.ResponseWriter, r *http.Request) {
 	claims := middleware.GetUserFromContext(r.Context())
 	if claims == nil {
@@ -347,6 +475,7 @@ func (h *ExecutionHandler) Search(w http.ResponseWriter, r *http.Request) {
 			OutputData:      exec.OutputData,
 			ErrorMessage:    exec.ErrorMessage,
 			ErrorNodeID:     exec.ErrorNodeID,
+			ErrorDetails:    errorDetails(exec.ErrorDetails),
 			NodesTotal:      exec.NodesTotal,
 			NodesCompleted:  exec.NodesCompleted,
 			QueuedAt:        exec.QueuedAt.Unix(),
@@ -454,3 +583,11 @@ func (h *ExecutionHandler) Stats(w http.ResponseWriter, r *http.Request) {
 
 	dto.JSON(w, http.StatusOK, stats)
 }
+
+// errorDetails leaves empty error details out of responses
+func errorDetails(details models.JSON) interface{} {
+	if len(details) == 0 {
+		return nil
+	}
+	return details
+}
@@ -10,8 +10,10 @@ import (
 	"github.com/google/uuid"
 	"github.com/linkflow-ai/linkflow/internal/api/dto"
 	"github.com/linkflow-ai/linkflow/internal/api/middleware"
+	"github.com/linkflow-ai/linkflow/internal/domain/models"
 	"github.com/linkflow-ai/linkflow/internal/domain/repositories"
 	"github.com/linkflow-ai/linkflow/internal/domain/services"
+	"github.com/linkflow-ai/linkflow/internal/pkg/queue"
 )
 
 // =============================================================================
@@ -778,11 +780,27 @@ func (h *AnalyticsHandler) GetWorkflowAnalytics(w http.ResponseWriter, r *http.R
 // =============================================================================
 
 type ExecutionReplayHandler struct {
-	replaySvc *services.ExecutionReplayService
+	replaySvc   *services.ExecutionReplayService
+	queueClient *queue.Client
 }
 
-func NewExecutionReplayHandler(replaySvc *services.ExecutionReplayService) *ExecutionReplayHandler {
-	return &ExecutionReplayHandler{replaySvc: replaySvc}
+func NewExecutionReplayHandler(replaySvc *services.ExecutionReplayService, queueClient *queue.Client) *ExecutionReplayHandler {
+	return &ExecutionReplayHandler{replaySvc: replaySvc, queueClient: queueClient}
+}
+
+// enqueue queues a replay execution, reusing its pre-created record
+func (h *ExecutionReplayHandler) enqueue(r *http.Request, execution *models.Execution) error {
+	_, err := h.queueClient.EnqueueWorkflowExecution(r.Context(), queue.WorkflowExecutionPayload{
+		WorkflowID:  execution.WorkflowID,
+		WorkspaceID: execution.WorkspaceID,
+		ExecutionID: execution.ID,
+		TriggeredBy: execution.TriggeredBy,
+		TriggerType: execution.TriggerType,
+		TriggerData: execution.TriggerData,
+		InputData:   execution.InputData,
+		Priority:    execution.Priority,
+	})
+	return err
 }
 
 func (h *ExecutionReplayHandler) Replay(w http.ResponseWriter, r *http.Request) {
@@ -804,6 +822,11 @@ func (h *ExecutionReplayHandler) Replay(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if err := h.enqueue(r, execution); err != nil {
+		dto.ErrorResponse(w, http.StatusInternalServerError, "failed to queue execution")
+		return
+	}
+
 	dto.Created(w, map[string]interface{}{
 		"id":      execution.ID,
 		"status":  execution.Status,
@@ -825,19 +848,29 @@ func (h *ExecutionReplayHandler) ReplayFromNode(w http.ResponseWriter, r *http.R
 	}
 
 	var req struct {
-		NodeID string `json:"node_id"`
+		NodeID     string `json:"node_id"`
+		StopNodeID string `json:"stop_node_id,omitempty"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		dto.BadRequest(w, "invalid request body")
 		return
 	}
+	if req.NodeID == "" {
+		dto.BadRequest(w, "node_id is required")
+		return
+	}
 
-	execution, err := h.replaySvc.ReplayFromNode(r.Context(), execID, req.NodeID, &claims.UserID)
+	execution, err := h.replaySvc.ReplayFromNode(r.Context(), execID, req.NodeID, req.StopNodeID, &claims.UserID)
 	if err != nil {
 		dto.ErrorResponse(w, http.StatusInternalServerError, "failed to replay execution from node")
 		return
 	}
 
+	if err := h.enqueue(r, execution); err != nil {
+		dto.ErrorResponse(w, http.StatusInternalServerError, "failed to queue execution")
+		return
+	}
+
 	dto.Created(w, map[string]interface{}{
 		"id":        execution.ID,
 		"status":    execution.Status,
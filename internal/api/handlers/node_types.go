@@ -12,6 +12,7 @@ import (
 	"github.com/linkflow-ai/linkflow/internal/api/middleware"
 	"github.com/linkflow-ai/linkflow/internal/domain/models"
 	"github.com/linkflow-ai/linkflow/internal/domain/services"
+	"github.com/linkflow-ai/linkflow/internal/worker/expression"
 	"github.com/linkflow-ai/linkflow/internal/worker/nodes"
 )
 
@@ -114,6 +115,15 @@ func (h *NodeTypeHandler) GetNodeType(w http.ResponseWriter, r *http.Request) {
 	dto.JSON(w, http.StatusOK, response)
 }
 
+// GetExpressionAutocomplete returns the functions and variables available in
+// expressions for the editor's autocomplete
+func (h *NodeTypeHandler) GetExpressionAutocomplete(w http.ResponseWriter, r *http.Request) {
+	dto.JSON(w, http.StatusOK, map[string]interface{}{
+		"functions": expression.Functions(),
+		"variables": expression.Variables(),
+	})
+}
+
 // GetNodeCategories returns available node categories
 func (h *NodeTypeHandler) GetNodeCategories(w http.ResponseWriter, r *http.Request) {
 	metas := nodes.ListAll()
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/linkflow-ai/linkflow/internal/api/dto"
+	"github.com/linkflow-ai/linkflow/internal/pkg/queue"
+)
+
+// QueueStatsHandler handles execution queue monitoring endpoints
+type QueueStatsHandler struct {
+	fairQueue *queue.FairQueue
+}
+
+// NewQueueStatsHandler creates a new queue stats handler
+func NewQueueStatsHandler(fairQueue *queue.FairQueue) *QueueStatsHandler {
+	return &QueueStatsHandler{fairQueue: fairQueue}
+}
+
+// GetStats returns the executions each workspace has staged, by queue
+// GET /api/v1/admin/queues/stats
+func (h *QueueStatsHandler) GetStats(w http.ResponseWriter, r *http.Request) {
+	if h.fairQueue == nil {
+		dto.ErrorResponse(w, http.StatusServiceUnavailable, "fair queueing not enabled")
+		return
+	}
+
+	depths, err := h.fairQueue.Depths(r.Context())
+	if err != nil {
+		dto.ErrorResponse(w, http.StatusInternalServerError, "failed to get stats: "+err.Error())
+		return
+	}
+
+	queues := map[string]int64{
+		queue.QueueCritical: 0,
+		queue.QueueDefault:  0,
+		queue.QueueLow:      0,
+	}
+	var total int64
+	for _, depth := range depths {
+		queues[depth.Queue] += depth.Depth
+		total += depth.Depth
+	}
+
+	dto.JSON(w, http.StatusOK, map[string]interface{}{
+		"enabled":    true,
+		"total":      total,
+		"queues":     queues,
+		"workspaces": depths,
+	})
+}
@@ -114,6 +114,7 @@ func (h *ScheduleHandler) Create(w http.ResponseWriter, r *http.Request) {
 		CronExpression: req.CronExpression,
 		Timezone:       req.Timezone,
 		InputData:      req.InputData,
+		Priority:       req.Priority,
 	})
 	if err != nil {
 		if err == services.ErrInvalidCron {
@@ -224,6 +225,7 @@ func (h *ScheduleHandler) Update(w http.ResponseWriter, r *http.Request) {
 		CronExpression: req.CronExpression,
 		Timezone:       req.Timezone,
 		InputData:      req.InputData,
+		Priority:       req.Priority,
 	})
 	if err != nil {
 		if err == services.ErrInvalidCron {
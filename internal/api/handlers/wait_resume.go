@@ -1,8 +1,12 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"html/template"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -11,20 +15,24 @@ import (
 	"github.com/linkflow-ai/linkflow/internal/domain/models"
 	"github.com/linkflow-ai/linkflow/internal/domain/repositories"
 	"github.com/linkflow-ai/linkflow/internal/domain/services"
+	"github.com/linkflow-ai/linkflow/internal/pkg/queue"
 )
 
 type WaitResumeHandler struct {
 	waitResumeMgr *services.WaitResumeManager
 	waitingRepo   *repositories.WaitingExecutionRepository
+	queueClient   *queue.Client
 }
 
 func NewWaitResumeHandler(
 	waitResumeMgr *services.WaitResumeManager,
 	waitingRepo *repositories.WaitingExecutionRepository,
+	queueClient *queue.Client,
 ) *WaitResumeHandler {
 	return &WaitResumeHandler{
 		waitResumeMgr: waitResumeMgr,
 		waitingRepo:   waitingRepo,
+		queueClient:   queueClient,
 	}
 }
 
@@ -92,6 +100,11 @@ func (h *WaitResumeHandler) Resume(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := h.continueExecution(r.Context(), waiting); err != nil {
+		dto.ErrorResponse(w, http.StatusInternalServerError, "failed to queue execution")
+		return
+	}
+
 	dto.JSON(w, http.StatusOK, map[string]interface{}{
 		"message":      "Execution resumed",
 		"execution_id": waiting.ExecutionID,
@@ -100,6 +113,132 @@ func (h *WaitResumeHandler) Resume(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// continueExecution queues a resumed execution to continue from its saved
+// state on a worker. The wait is reopened if that fails.
+func (h *WaitResumeHandler) continueExecution(ctx context.Context, waiting *models.WaitingExecution) error {
+	_, err := h.queueClient.EnqueueWorkflowExecution(ctx, queue.WorkflowExecutionPayload{
+		WorkflowID:  waiting.WorkflowID,
+		WorkspaceID: waiting.WorkspaceID,
+		ExecutionID: waiting.ExecutionID,
+		WaitingID:   waiting.ID,
+		Priority:    waiting.Priority,
+	})
+	if err != nil {
+		_ = h.waitingRepo.UpdateStatus(ctx, waiting.ID, services.WaitStatusWaiting)
+		return err
+	}
+	return nil
+}
+
+// ApprovalPage asks for confirmation of an approve/reject link. Opening the
+// link records nothing, so mail scanners that follow links can't decide.
+func (h *WaitResumeHandler) ApprovalPage(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+	decision := approvalDecision(r)
+
+	waiting, err := h.waitResumeMgr.GetWaitingExecution(r.Context(), token)
+	if err != nil {
+		dto.ErrorResponse(w, http.StatusNotFound, "waiting execution not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_ = approvalPageTemplate.Execute(w, map[string]interface{}{
+		"Decision": decision,
+		"Open":     waiting.Status == services.WaitStatusWaiting,
+		"By":       r.URL.Query().Get("by"),
+	})
+}
+
+// ApprovalRequest is the response to an approval
+type ApprovalRequest struct {
+	RespondedBy string `json:"responded_by"`
+	Comment     string `json:"comment"`
+}
+
+// Decide records an approve/reject decision and resumes the execution. It
+// accepts the confirmation form or a JSON body.
+func (h *WaitResumeHandler) Decide(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+	decision := approvalDecision(r)
+
+	var req ApprovalRequest
+	form := !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json")
+	if form {
+		_ = r.ParseForm()
+		req.RespondedBy = r.FormValue("by")
+		req.Comment = r.FormValue("comment")
+	} else {
+		_ = json.NewDecoder(r.Body).Decode(&req)
+	}
+	if req.RespondedBy == "" {
+		req.RespondedBy = r.URL.Query().Get("by")
+	}
+
+	waiting, err := h.waitResumeMgr.ResumeExecution(r.Context(), token, models.JSON{
+		"decision":    decision,
+		"respondedBy": req.RespondedBy,
+		"comment":     req.Comment,
+		"respondedAt": time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		dto.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.continueExecution(r.Context(), waiting); err != nil {
+		dto.ErrorResponse(w, http.StatusInternalServerError, "failed to queue execution")
+		return
+	}
+
+	if form {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_ = approvalDoneTemplate.Execute(w, map[string]interface{}{"Decision": decision})
+		return
+	}
+
+	dto.JSON(w, http.StatusOK, map[string]interface{}{
+		"message":      "Execution resumed",
+		"decision":     decision,
+		"execution_id": waiting.ExecutionID,
+		"workflow_id":  waiting.WorkflowID,
+		"node_id":      waiting.NodeID,
+	})
+}
+
+// approvalDecision maps the last path segment to the decision
+func approvalDecision(r *http.Request) string {
+	if strings.HasSuffix(r.URL.Path, "/approve") {
+		return "approved"
+	}
+	return "rejected"
+}
+
+var approvalPageTemplate = template.Must(template.New("approval").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Approval</title></head>
+<body style="font-family: sans-serif; max-width: 480px; margin: 40px auto;">
+{{if .Open}}
+<h2>{{if eq .Decision "approved"}}Approve{{else}}Reject{{end}} this request?</h2>
+<form method="post">
+<p><label>Your name<br><input name="by" value="{{.By}}" style="width: 100%"></label></p>
+<p><label>Comment<br><textarea name="comment" rows="4" style="width: 100%"></textarea></label></p>
+<button type="submit">{{if eq .Decision "approved"}}Approve{{else}}Reject{{end}}</button>
+</form>
+{{else}}
+<h2>This request has already been answered or has expired.</h2>
+{{end}}
+</body>
+</html>`))
+
+var approvalDoneTemplate = template.Must(template.New("approvalDone").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Approval</title></head>
+<body style="font-family: sans-serif; max-width: 480px; margin: 40px auto;">
+<h2>Thanks, the request was {{.Decision}}.</h2>
+</body>
+</html>`))
+
 // GetWaitingStatus returns status of a waiting execution by token
 func (h *WaitResumeHandler) GetWaitingStatus(w http.ResponseWriter, r *http.Request) {
 	token := chi.URLParam(r, "token")
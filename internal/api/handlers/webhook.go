@@ -129,7 +129,7 @@ func (h *WebhookHandler) handleWithStream(w http.ResponseWriter, r *http.Request
 		}
 
 		triggerData := h.buildTriggerData(r, body)
-		result, err := h.waitForExecution(ctx, workflow, triggerData, webhook.ResponseTimeout)
+		result, err := h.waitForExecution(ctx, workflow, triggerData, webhook.ResponseTimeout, webhook.Priority)
 		if err != nil {
 			dto.ErrorResponse(w, http.StatusInternalServerError, "execution failed: "+err.Error())
 			return
@@ -179,7 +179,7 @@ func (h *WebhookHandler) handleDirect(w http.ResponseWriter, r *http.Request, ct
 	triggerData := h.buildTriggerData(r, body)
 
 	// Queue workflow execution
-	err = h.queueWorkflowExecution(ctx, workflow, triggerData)
+	err = h.queueWorkflowExecution(ctx, workflow, triggerData, webhook.Priority)
 	if err != nil {
 		log.Error().Err(err).Str("workflow_id", workflow.ID.String()).Msg("Failed to queue workflow")
 		dto.ErrorResponse(w, http.StatusInternalServerError, "failed to queue execution")
@@ -193,7 +193,7 @@ func (h *WebhookHandler) handleDirect(w http.ResponseWriter, r *http.Request, ct
 
 	// Return response based on webhook config
 	if webhook.ResponseMode == "wait" {
-		result, err := h.waitForExecution(ctx, workflow, triggerData, webhook.ResponseTimeout)
+		result, err := h.waitForExecution(ctx, workflow, triggerData, webhook.ResponseTimeout, webhook.Priority)
 		if err != nil {
 			log.Error().Err(err).Msg("Execution failed or timed out")
 			dto.ErrorResponse(w, http.StatusInternalServerError, "execution failed: "+err.Error())
@@ -229,7 +229,7 @@ func (h *WebhookHandler) buildTriggerData(r *http.Request, body []byte) models.J
 	return triggerData
 }
 
-func (h *WebhookHandler) waitForExecution(ctx context.Context, workflow *models.Workflow, triggerData models.JSON, timeout int) (map[string]interface{}, error) {
+func (h *WebhookHandler) waitForExecution(ctx context.Context, workflow *models.Workflow, triggerData models.JSON, timeout, priority int) (map[string]interface{}, error) {
 	if timeout <= 0 || timeout > 30 {
 		timeout = 30 // Default 30 second timeout for sync webhooks
 	}
@@ -240,6 +240,8 @@ func (h *WebhookHandler) waitForExecution(ctx context.Context, workflow *models.
 		WorkspaceID: workflow.WorkspaceID,
 		TriggerType: "webhook",
 		TriggerData: triggerData,
+		Priority:    queue.NormalizePriority(priority),
+		Queue:       queue.QueueForPriority(priority),
 	})
 	if err != nil {
 		return nil, err
@@ -252,6 +254,7 @@ func (h *WebhookHandler) waitForExecution(ctx context.Context, workflow *models.
 		ExecutionID: execution.ID,
 		TriggerType: "webhook",
 		InputData:   triggerData,
+		Priority:    priority,
 	}
 	_, _ = h.queueClient.EnqueueWorkflowExecution(ctx, payload)
 
@@ -274,7 +277,7 @@ func (h *WebhookHandler) waitForExecution(ctx context.Context, workflow *models.
 			output["status"] = "completed"
 			return output, nil
 			
-		case models.ExecutionStatusFailed, models.ExecutionStatusCancelled:
+		case models.ExecutionStatusFailed, models.ExecutionStatusCancelled, models.ExecutionStatusTimedOut:
 			errMsg := exec.Status
 			if exec.ErrorMessage != nil {
 				errMsg = *exec.ErrorMessage
@@ -362,16 +365,35 @@ func (h *WebhookHandler) verifySignature(r *http.Request, body []byte, secret st
 	return true
 }
 
-func (h *WebhookHandler) queueWorkflowExecution(ctx context.Context, workflow *models.Workflow, triggerData models.JSON) error {
+func (h *WebhookHandler) queueWorkflowExecution(ctx context.Context, workflow *models.Workflow, triggerData models.JSON, priority int) error {
+	// Create the execution up front, so its ID can be looked up right away
+	priority = queue.EffectivePriority("webhook", priority)
+	execution, err := h.executionSvc.Create(ctx, services.CreateExecutionInput{
+		WorkflowID:  workflow.ID,
+		WorkspaceID: workflow.WorkspaceID,
+		TriggerType: "webhook",
+		InputData:   triggerData,
+		Priority:    priority,
+		Queue:       queue.QueueForPriority(priority),
+	})
+	if err != nil {
+		return err
+	}
+
 	payload := queue.WorkflowExecutionPayload{
 		WorkflowID:  workflow.ID,
 		WorkspaceID: workflow.WorkspaceID,
+		ExecutionID: execution.ID,
 		TriggerType: "webhook",
 		InputData:   triggerData,
+		Priority:    priority,
 	}
 
-	_, err := h.queueClient.EnqueueWorkflowExecution(ctx, payload)
-	return err
+	if _, err := h.queueClient.EnqueueWorkflowExecution(ctx, payload); err != nil {
+		_ = h.executionSvc.Fail(ctx, execution.ID, "failed to queue execution", nil)
+		return err
+	}
+	return nil
 }
 
 // HandleTest handles test webhook requests (for testing during workflow creation)
@@ -417,7 +439,7 @@ func (h *WebhookHandler) HandleTest(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Queue execution
-	err = h.queueWorkflowExecution(r.Context(), workflow, triggerData)
+	err = h.queueWorkflowExecution(r.Context(), workflow, triggerData, queue.PriorityInteractive)
 	if err != nil {
 		dto.ErrorResponse(w, http.StatusInternalServerError, "failed to queue execution")
 		return
@@ -20,20 +20,23 @@ import (
 )
 
 type WorkflowHandler struct {
-	workflowSvc *services.WorkflowService
-	billingSvc  *services.BillingService
-	queueClient *queue.Client
+	workflowSvc  *services.WorkflowService
+	executionSvc *services.ExecutionService
+	billingSvc   *services.BillingService
+	queueClient  *queue.Client
 }
 
 func NewWorkflowHandler(
 	workflowSvc *services.WorkflowService,
+	executionSvc *services.ExecutionService,
 	billingSvc *services.BillingService,
 	queueClient *queue.Client,
 ) *WorkflowHandler {
 	return &WorkflowHandler{
-		workflowSvc: workflowSvc,
-		billingSvc:  billingSvc,
-		queueClient: queueClient,
+		workflowSvc:  workflowSvc,
+		executionSvc: executionSvc,
+		billingSvc:   billingSvc,
+		queueClient:  queueClient,
 	}
 }
 
@@ -361,23 +364,58 @@ func (h *WorkflowHandler) Execute(w http.ResponseWriter, r *http.Request) {
 
 	var req dto.ExecuteWorkflowRequest
 	_ = json.NewDecoder(r.Body).Decode(&req)
+	if err := validator.Validate(&req); err != nil {
+		dto.ValidationErrorResponse(w, err)
+		return
+	}
+
+	triggerType := models.TriggerManual
+	if req.Test {
+		triggerType = models.TriggerTest
+	}
+
+	// Dry runs simulate nodes with side effects instead of executing them
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+
+	// Create the execution up front, so its ID can be looked up right away
+	priority := queue.EffectivePriority(triggerType, req.Priority)
+	execution, err := h.executionSvc.Create(r.Context(), services.CreateExecutionInput{
+		WorkflowID:  workflowID,
+		WorkspaceID: wsCtx.WorkspaceID,
+		TriggeredBy: &claims.UserID,
+		TriggerType: triggerType,
+		InputData:   req.InputData,
+		Priority:    priority,
+		Queue:       queue.QueueForPriority(priority),
+	})
+	if err != nil {
+		dto.ErrorResponse(w, http.StatusInternalServerError, "failed to create execution")
+		return
+	}
 
 	// Queue execution
 	task, err := h.queueClient.EnqueueWorkflowExecution(r.Context(), queue.WorkflowExecutionPayload{
 		WorkflowID:  workflowID,
 		WorkspaceID: wsCtx.WorkspaceID,
+		ExecutionID: execution.ID,
 		TriggeredBy: &claims.UserID,
-		TriggerType: models.TriggerManual,
+		TriggerType: triggerType,
 		InputData:   req.InputData,
+		DryRun:      dryRun,
+		Priority:    priority,
 	})
 	if err != nil {
+		_ = h.executionSvc.Fail(r.Context(), execution.ID, "failed to queue execution", nil)
 		dto.ErrorResponse(w, http.StatusInternalServerError, "failed to queue execution")
 		return
 	}
 
-	dto.Accepted(w, map[string]string{
-		"task_id": task.ID,
-		"status":  "queued",
+	dto.Accepted(w, map[string]interface{}{
+		"task_id":      task.ID,
+		"execution_id": execution.ID,
+		"status":       "queued",
+		"dry_run":      dryRun,
+		"queue":        task.Queue,
 	})
 }
 
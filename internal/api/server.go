@@ -23,6 +23,7 @@ import (
 	"github.com/linkflow-ai/linkflow/internal/pkg/queue"
 	pkgredis "github.com/linkflow-ai/linkflow/internal/pkg/redis"
 	"github.com/linkflow-ai/linkflow/internal/pkg/streams"
+	"github.com/linkflow-ai/linkflow/internal/worker/processor"
 	"github.com/rs/cors"
 	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
@@ -111,8 +112,9 @@ func NewServer(
 	authHandler := handlers.NewAuthHandler(svc.Auth, jwtManager, redisClient)
 	userHandler := handlers.NewUserHandler(svc.User)
 	workspaceHandler := handlers.NewWorkspaceHandler(svc.Workspace, svc.Billing)
-	workflowHandler := handlers.NewWorkflowHandler(svc.Workflow, svc.Billing, queueClient)
+	workflowHandler := handlers.NewWorkflowHandler(svc.Workflow, svc.Execution, svc.Billing, queueClient)
 	executionHandler := handlers.NewExecutionHandler(svc.Execution, queueClient)
+	executionHandler.SetCancellationManager(processor.NewCancellationManager(redisClient.Client))
 	credentialHandler := handlers.NewCredentialHandler(svc.Credential)
 	scheduleHandler := handlers.NewScheduleHandler(svc.Schedule)
 	billingHandler := handlers.NewBillingHandler(svc.Billing)
@@ -131,6 +133,12 @@ func NewServer(
 		log.Info().Msg("Webhook stream buffering enabled")
 	}
 
+	// Per-workspace execution queue depth
+	var queueStatsHandler *handlers.QueueStatsHandler
+	if fairQueue := queueClient.FairQueue(); fairQueue != nil {
+		queueStatsHandler = handlers.NewQueueStatsHandler(fairQueue)
+	}
+
 	// New feature handlers
 	var oauthHandler *handlers.OAuthHandler
 	if svc.OAuth != nil {
@@ -154,7 +162,7 @@ func NewServer(
 
 	var waitResumeHandler *handlers.WaitResumeHandler
 	if svc.WaitResumeMgr != nil && repos != nil && repos.WaitingExec != nil {
-		waitResumeHandler = handlers.NewWaitResumeHandler(svc.WaitResumeMgr, repos.WaitingExec)
+		waitResumeHandler = handlers.NewWaitResumeHandler(svc.WaitResumeMgr, repos.WaitingExec, queueClient)
 	}
 
 	// New feature handlers
@@ -195,7 +203,7 @@ func NewServer(
 
 	var replayHandler *handlers.ExecutionReplayHandler
 	if svc.ExecReplay != nil {
-		replayHandler = handlers.NewExecutionReplayHandler(svc.ExecReplay)
+		replayHandler = handlers.NewExecutionReplayHandler(svc.ExecReplay, queueClient)
 	}
 
 	// Auth middleware
@@ -218,6 +226,16 @@ func NewServer(
 			r.Get("/auth/oauth/{provider}", authHandler.OAuthRedirect)
 			r.Get("/auth/oauth/{provider}/callback", authHandler.OAuthCallback)
 
+			// Wait/Resume (the token authorizes the call)
+			if waitResumeHandler != nil {
+				r.Post("/resume/{token}", waitResumeHandler.Resume)
+				r.Get("/resume/{token}/status", waitResumeHandler.GetWaitingStatus)
+				r.Get("/resume/{token}/approve", waitResumeHandler.ApprovalPage)
+				r.Post("/resume/{token}/approve", waitResumeHandler.Decide)
+				r.Get("/resume/{token}/reject", waitResumeHandler.ApprovalPage)
+				r.Post("/resume/{token}/reject", waitResumeHandler.Decide)
+			}
+
 			// Health
 			r.Get("/health", healthHandler.Health)
 			r.Get("/health/live", healthHandler.Live)
@@ -256,18 +274,13 @@ func NewServer(
 			r.Get("/node-types", nodeTypeHandler.ListNodeTypes)
 			r.Get("/node-types/categories", nodeTypeHandler.GetNodeCategories)
 			r.Get("/node-types/{nodeType}", nodeTypeHandler.GetNodeType)
+			r.Get("/expressions/autocomplete", nodeTypeHandler.GetExpressionAutocomplete)
 
 			// OAuth
 			if oauthHandler != nil {
 				r.Get("/oauth/providers", oauthHandler.GetProviders)
 			}
 
-			// Wait/Resume (public resume endpoint)
-			if waitResumeHandler != nil {
-				r.Post("/resume/{token}", waitResumeHandler.Resume)
-				r.Get("/resume/{token}/status", waitResumeHandler.GetWaitingStatus)
-			}
-
 			// Workspaces
 			r.Get("/workspaces", workspaceHandler.List)
 			r.Post("/workspaces", workspaceHandler.Create)
@@ -310,6 +323,8 @@ func NewServer(
 				r.Delete("/executions/bulk", executionHandler.BulkDelete)
 				r.Get("/executions/{executionID}", executionHandler.Get)
 				r.Post("/executions/{executionID}/cancel", executionHandler.Cancel)
+				r.Post("/executions/{executionID}/pause", executionHandler.Pause)
+				r.Post("/executions/{executionID}/resume", executionHandler.Resume)
 				r.Post("/executions/{executionID}/retry", executionHandler.Retry)
 				r.Get("/executions/{executionID}/nodes", executionHandler.GetNodes)
 
@@ -454,6 +469,11 @@ func NewServer(
 			r.Post("/streams/webhooks/replay", streamStatsHandler.ReplayDLQ)
 			r.Post("/streams/webhooks/trim", streamStatsHandler.Trim)
 		}
+
+		// Execution queue stats (fair queueing)
+		if queueStatsHandler != nil {
+			r.Get("/queues/stats", queueStatsHandler.GetStats)
+		}
 	})
 
 	// Metrics endpoint (Prometheus)
@@ -9,10 +9,13 @@ const (
 	EventExecutionCompleted EventType = "execution.completed"
 	EventExecutionFailed    EventType = "execution.failed"
 	EventExecutionCancelled EventType = "execution.cancelled"
+	EventExecutionPaused     EventType = "execution.paused"
 	EventExecutionProgress  EventType = "execution.progress"
 	EventNodeStarted        EventType = "node.started"
 	EventNodeCompleted      EventType = "node.completed"
 	EventNodeFailed         EventType = "node.failed"
+	EventNodeSkipped        EventType = "node.skipped"
+	EventNodeRetrying       EventType = "node.retrying"
 	EventWorkflowUpdated    EventType = "workflow.updated"
 	EventWorkflowActivated  EventType = "workflow.activated"
 	EventWorkflowDeactivated EventType = "workflow.deactivated"
@@ -84,10 +87,14 @@ func NodeCompletedEvent(executionID, nodeID string, output interface{}, duration
 	})
 }
 
-func NodeFailedEvent(executionID, nodeID, errorMessage string) *Event {
-	return NewEvent(EventNodeFailed, map[string]interface{}{
+func NodeFailedEvent(executionID, nodeID, errorMessage string, errorDetails map[string]interface{}) *Event {
+	data := map[string]interface{}{
 		"execution_id":  executionID,
 		"node_id":       nodeID,
 		"error_message": errorMessage,
-	})
+	}
+	if errorDetails != nil {
+		data["error_details"] = errorDetails
+	}
+	return NewEvent(EventNodeFailed, data)
 }
@@ -57,6 +57,7 @@ type Plan struct {
 	// Execution Limits
 	ExecutionTimeout   int `gorm:"not null;default:30" json:"execution_timeout"`       // Seconds
 	MaxNodesPerWorkflow int `gorm:"not null;default:50" json:"max_nodes_per_workflow"`
+	QueueWeight         int `gorm:"not null;default:1" json:"queue_weight"` // Share of the worker queues relative to other workspaces
 
 	// Data Retention
 	RetentionDays    int `gorm:"not null" json:"retention_days"`
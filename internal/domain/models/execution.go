@@ -19,19 +19,23 @@ type Execution struct {
 	OutputData        JSON       `gorm:"type:jsonb" json:"output_data,omitempty"`
 	ErrorMessage      *string    `gorm:"type:text" json:"error_message,omitempty"`
 	ErrorNodeID       *string    `gorm:"size:100" json:"error_node_id,omitempty"`
+	ErrorDetails      JSON       `gorm:"type:jsonb" json:"error_details,omitempty"`
 	QueuedAt          time.Time  `gorm:"default:now()" json:"queued_at"`
 	StartedAt         *time.Time `json:"started_at,omitempty"`
 	CompletedAt       *time.Time `json:"completed_at,omitempty"`
 	PausedAt          *time.Time `json:"paused_at,omitempty"`
 	ResumedAt         *time.Time `json:"resumed_at,omitempty"`
+	PauseState        JSON       `gorm:"type:jsonb" json:"-"` // State a paused execution continues from
 	NodesTotal        int        `gorm:"default:0" json:"nodes_total"`
 	NodesCompleted    int        `gorm:"default:0" json:"nodes_completed"`
 	RetryCount        int        `gorm:"default:0" json:"retry_count"`
 	MaxRetries        int        `gorm:"default:3" json:"max_retries"`
 	Priority          int        `gorm:"default:5;index" json:"priority"` // 1-10, higher = more priority
+	Queue             string     `gorm:"size:20" json:"queue,omitempty"`  // Queue the execution ran from
 	TimeoutSeconds    int        `gorm:"default:3600" json:"timeout_seconds"`
 	ParentExecutionID *uuid.UUID `gorm:"type:uuid" json:"parent_execution_id,omitempty"`
 	BatchID           *uuid.UUID `gorm:"type:uuid;index" json:"batch_id,omitempty"` // For bulk executions
+	UsageTracked      bool       `gorm:"default:false" json:"-"`                    // Billing usage consumed
 	CreatedAt         time.Time  `json:"created_at"`
 
 	// Relations
@@ -60,10 +64,13 @@ type NodeExecution struct {
 	InputData    JSON       `gorm:"type:jsonb" json:"input_data,omitempty"`
 	OutputData   JSON       `gorm:"type:jsonb" json:"output_data,omitempty"`
 	ErrorMessage *string    `gorm:"type:text" json:"error_message,omitempty"`
+	ErrorDetails JSON       `gorm:"type:jsonb" json:"error_details,omitempty"`
 	StartedAt    *time.Time `json:"started_at,omitempty"`
 	CompletedAt  *time.Time `json:"completed_at,omitempty"`
 	DurationMs   *int       `json:"duration_ms,omitempty"`
 	RetryCount   int        `gorm:"default:0" json:"retry_count"`
+	Cached       bool       `gorm:"default:false" json:"cached"`
+	Pinned       bool       `gorm:"default:false" json:"pinned"`
 	CreatedAt    time.Time  `json:"created_at"`
 
 	Execution Execution `gorm:"foreignKey:ExecutionID" json:"-"`
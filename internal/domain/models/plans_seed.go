@@ -25,6 +25,7 @@ func DefaultPlans() []Plan {
 			WebhooksLimit:       2,
 			ExecutionTimeout:    30,
 			MaxNodesPerWorkflow: 20,
+			QueueWeight:         1,
 			RetentionDays:       7,
 			LogRetentionDays:    3,
 
@@ -54,6 +55,7 @@ func DefaultPlans() []Plan {
 			WebhooksLimit:       10,
 			ExecutionTimeout:    60,
 			MaxNodesPerWorkflow: 50,
+			QueueWeight:         2,
 			RetentionDays:       30,
 			LogRetentionDays:    7,
 
@@ -83,6 +85,7 @@ func DefaultPlans() []Plan {
 			WebhooksLimit:       50,
 			ExecutionTimeout:    300, // 5 minutes
 			MaxNodesPerWorkflow: 100,
+			QueueWeight:         4,
 			RetentionDays:       90,
 			LogRetentionDays:    30,
 
@@ -112,6 +115,7 @@ func DefaultPlans() []Plan {
 			WebhooksLimit:       200,
 			ExecutionTimeout:    1800, // 30 minutes
 			MaxNodesPerWorkflow: 200,
+			QueueWeight:         8,
 			RetentionDays:       365,
 			LogRetentionDays:    90,
 
@@ -141,6 +145,7 @@ func DefaultPlans() []Plan {
 			WebhooksLimit:       -1,
 			ExecutionTimeout:    3600, // 1 hour
 			MaxNodesPerWorkflow: -1,
+			QueueWeight:         16,
 			RetentionDays:       -1, // Custom
 			LogRetentionDays:    365,
 
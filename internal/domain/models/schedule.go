@@ -18,6 +18,7 @@ type Schedule struct {
 	Timezone        string         `gorm:"size:50;default:UTC" json:"timezone"`
 	IsActive        bool           `gorm:"default:true" json:"is_active"`
 	InputData       JSON           `gorm:"type:jsonb" json:"input_data,omitempty"`
+	Priority        int            `gorm:"default:5" json:"priority"` // 1-10 priority of the executions it starts
 	NextRunAt       *time.Time     `gorm:"index" json:"next_run_at,omitempty"`
 	LastRunAt       *time.Time     `json:"last_run_at,omitempty"`
 	LastExecutionID *uuid.UUID     `gorm:"type:uuid" json:"last_execution_id,omitempty"`
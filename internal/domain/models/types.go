@@ -70,7 +70,9 @@ const (
 	ExecutionStatusCompleted = "completed"
 	ExecutionStatusFailed    = "failed"
 	ExecutionStatusCancelled = "cancelled"
-	ExecutionStatusTimeout   = "timeout"
+	ExecutionStatusTimedOut  = "timed_out"
+	ExecutionStatusWaiting   = "waiting"
+	ExecutionStatusPaused    = "paused"
 )
 
 // Node execution status constants
@@ -99,11 +101,15 @@ const (
 
 // Trigger types
 const (
-	TriggerManual      = "manual"
-	TriggerSchedule    = "schedule"
-	TriggerWebhook     = "webhook"
-	TriggerAPI         = "api"
-	TriggerSubWorkflow = "sub_workflow"
+	TriggerManual        = "manual"
+	TriggerTest          = "test"
+	TriggerSchedule      = "schedule"
+	TriggerWebhook       = "webhook"
+	TriggerAPI           = "api"
+	TriggerSubWorkflow   = "sub_workflow"
+	TriggerReplay        = "replay"
+	TriggerPartialReplay = "partial_replay"
+	TriggerError         = "error"
 )
 
 // Credential types
@@ -14,11 +14,12 @@ type WaitingExecution struct {
 	WorkspaceID   uuid.UUID  `gorm:"type:uuid;index;not null" json:"workspace_id"`
 	NodeID        string     `gorm:"size:100;not null" json:"node_id"`
 	ResumeToken   string     `gorm:"size:255;uniqueIndex;not null" json:"resume_token"`
-	ResumeType    string     `gorm:"size:50;not null" json:"resume_type"` // webhook, manual, timeout
+	ResumeType    string     `gorm:"size:50;not null" json:"resume_type"` // timer, webhook
 	WebhookPath   *string    `gorm:"size:255;index" json:"webhook_path,omitempty"`
 	TimeoutAt     *time.Time `json:"timeout_at,omitempty"`
 	ResumedAt     *time.Time `json:"resumed_at,omitempty"`
 	ResumeData    JSON       `gorm:"type:jsonb" json:"resume_data,omitempty"`
+	Priority      int        `gorm:"default:5" json:"priority"`
 	ExecutionData JSON       `gorm:"type:jsonb" json:"execution_data,omitempty"` // Serialized execution state
 	Status        string     `gorm:"size:20;not null;default:waiting" json:"status"` // waiting, resumed, expired
 	CreatedAt     time.Time  `json:"created_at"`
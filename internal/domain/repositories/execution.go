@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/linkflow-ai/linkflow/internal/domain/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ExecutionRepository struct {
@@ -87,7 +88,7 @@ func (r *ExecutionRepository) UpdateStatus(ctx context.Context, executionID uuid
 	if status == models.ExecutionStatusRunning {
 		now := time.Now()
 		updates["started_at"] = now
-	} else if status == models.ExecutionStatusCompleted || status == models.ExecutionStatusFailed || status == models.ExecutionStatusCancelled || status == models.ExecutionStatusTimeout {
+	} else if status == models.ExecutionStatusCompleted || status == models.ExecutionStatusFailed || status == models.ExecutionStatusCancelled || status == models.ExecutionStatusTimedOut {
 		now := time.Now()
 		updates["completed_at"] = now
 	}
@@ -97,15 +98,79 @@ func (r *ExecutionRepository) UpdateStatus(ctx context.Context, executionID uuid
 		Updates(updates).Error
 }
 
-func (r *ExecutionRepository) SetError(ctx context.Context, executionID uuid.UUID, errorMessage string, errorNodeID *string) error {
+func (r *ExecutionRepository) SetQueue(ctx context.Context, executionID uuid.UUID, priority int, queue string) error {
+	return r.DB().WithContext(ctx).Model(&models.Execution{}).
+		Where("id = ?", executionID).
+		Updates(map[string]interface{}{
+			"priority": priority,
+			"queue":    queue,
+		}).Error
+}
+
+// MarkResumed sets a waiting or paused execution running again, keeping its
+// start time
+func (r *ExecutionRepository) MarkResumed(ctx context.Context, executionID uuid.UUID) error {
+	return r.DB().WithContext(ctx).Model(&models.Execution{}).
+		Where("id = ?", executionID).
+		Updates(map[string]interface{}{
+			"status":      models.ExecutionStatusRunning,
+			"resumed_at":  time.Now(),
+			"pause_state": nil,
+		}).Error
+}
+
+// MarkPaused stores the state of a running execution that paused. It reports
+// false when the execution is no longer running.
+func (r *ExecutionRepository) MarkPaused(ctx context.Context, executionID uuid.UUID, state models.JSON) (bool, error) {
+	result := r.DB().WithContext(ctx).Model(&models.Execution{}).
+		Where("id = ? AND status = ?", executionID, models.ExecutionStatusRunning).
+		Updates(map[string]interface{}{
+			"status":      models.ExecutionStatusPaused,
+			"paused_at":   time.Now(),
+			"pause_state": state,
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+// TransitionStatus changes an execution's status from one to another. It
+// reports false when the execution wasn't in the from status.
+func (r *ExecutionRepository) TransitionStatus(ctx context.Context, executionID uuid.UUID, from, to string) (bool, error) {
+	result := r.DB().WithContext(ctx).Model(&models.Execution{}).
+		Where("id = ? AND status = ?", executionID, from).
+		Update("status", to)
+	return result.RowsAffected == 1, result.Error
+}
+
+// MarkUsageTracked flags the execution's usage as tracked. It reports false
+// when it already was.
+func (r *ExecutionRepository) MarkUsageTracked(ctx context.Context, executionID uuid.UUID) (bool, error) {
+	result := r.DB().WithContext(ctx).Model(&models.Execution{}).
+		Where("id = ? AND usage_tracked = ?", executionID, false).
+		Update("usage_tracked", true)
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *ExecutionRepository) SetError(ctx context.Context, executionID uuid.UUID, errorMessage string, errorNodeID *string, errorDetails models.JSON) error {
+	return r.setFailure(ctx, executionID, models.ExecutionStatusFailed, errorMessage, errorNodeID, errorDetails)
+}
+
+// SetTimedOut fails an execution that exceeded its timeout
+func (r *ExecutionRepository) SetTimedOut(ctx context.Context, executionID uuid.UUID, errorMessage string, errorNodeID *string, errorDetails models.JSON) error {
+	return r.setFailure(ctx, executionID, models.ExecutionStatusTimedOut, errorMessage, errorNodeID, errorDetails)
+}
+
+func (r *ExecutionRepository) setFailure(ctx context.Context, executionID uuid.UUID, status, errorMessage string, errorNodeID *string, errorDetails models.JSON) error {
 	updates := map[string]interface{}{
-		"status":        models.ExecutionStatusFailed,
+		"status":        status,
 		"error_message": errorMessage,
 		"completed_at":  time.Now(),
 	}
 	if errorNodeID != nil {
 		updates["error_node_id"] = *errorNodeID
 	}
+	if errorDetails != nil {
+		updates["error_details"] = errorDetails
+	}
 
 	return r.DB().WithContext(ctx).Model(&models.Execution{}).
 		Where("id = ?", executionID).
@@ -251,7 +316,7 @@ func (r *ExecutionRepository) GetStats(ctx context.Context, workspaceID uuid.UUI
 		"running":    int64(0),
 		"queued":     int64(0),
 		"cancelled":  int64(0),
-		"timeout":    int64(0),
+		"timed_out":  int64(0),
 		"by_status":  map[string]int64{},
 		"start_time": start.Format(time.RFC3339),
 		"end_time":   end.Format(time.RFC3339),
@@ -266,6 +331,8 @@ func (r *ExecutionRepository) GetStats(ctx context.Context, workspaceID uuid.UUI
 	}
 	stats["total"] = total
 	stats["by_status"] = byStatus
+	// Former name of timed_out, kept for existing clients
+	stats["timeout"] = stats[models.ExecutionStatusTimedOut]
 
 	// Get average duration for completed executions
 	var avgDuration struct {
@@ -293,6 +360,17 @@ func NewNodeExecutionRepository(db *gorm.DB) *NodeExecutionRepository {
 	}
 }
 
+// CreateBatch inserts node executions in batches. Records whose ID exists
+// replace the stored one.
+func (r *NodeExecutionRepository) CreateBatch(ctx context.Context, nodeExecutions []models.NodeExecution) error {
+	if len(nodeExecutions) == 0 {
+		return nil
+	}
+	return r.DB().WithContext(ctx).
+		Clauses(clause.OnConflict{UpdateAll: true}).
+		CreateInBatches(nodeExecutions, 100).Error
+}
+
 func (r *NodeExecutionRepository) FindByExecutionID(ctx context.Context, executionID uuid.UUID) ([]models.NodeExecution, error) {
 	var nodeExecutions []models.NodeExecution
 	err := r.DB().WithContext(ctx).
@@ -302,6 +380,17 @@ func (r *NodeExecutionRepository) FindByExecutionID(ctx context.Context, executi
 	return nodeExecutions, err
 }
 
+// ClearData drops the input and output data of an execution's node
+// records, keeping their status and timing
+func (r *NodeExecutionRepository) ClearData(ctx context.Context, executionID uuid.UUID) error {
+	return r.DB().WithContext(ctx).Model(&models.NodeExecution{}).
+		Where("execution_id = ?", executionID).
+		Updates(map[string]interface{}{
+			"input_data":  nil,
+			"output_data": nil,
+		}).Error
+}
+
 func (r *NodeExecutionRepository) FindByExecutionAndNode(ctx context.Context, executionID uuid.UUID, nodeID string) (*models.NodeExecution, error) {
 	var nodeExecution models.NodeExecution
 	err := r.DB().WithContext(ctx).
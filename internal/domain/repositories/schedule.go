@@ -101,7 +101,7 @@ func (r *ScheduleRepository) FindDueBatch(ctx context.Context, limit, offset int
 	return schedules, err
 }
 
-func (r *ScheduleRepository) FindDueByPriority(ctx context.Context, priority string) ([]models.Schedule, error) {
+func (r *ScheduleRepository) FindDueByPriority(ctx context.Context, priority int) ([]models.Schedule, error) {
 	var schedules []models.Schedule
 	err := r.DB().WithContext(ctx).
 		Preload("Workflow").
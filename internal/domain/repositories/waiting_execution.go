@@ -85,6 +85,28 @@ func (r *WaitingExecutionRepository) UpdateStatus(ctx context.Context, id uuid.U
 		Updates(updates).Error
 }
 
+// Claim marks a waiting execution resumed with data, unless it was resumed
+// or expired in the meantime. It reports whether the caller claimed it.
+func (r *WaitingExecutionRepository) Claim(ctx context.Context, id uuid.UUID, resumedAt time.Time, resumeData models.JSON) (bool, error) {
+	result := r.DB().WithContext(ctx).Model(&models.WaitingExecution{}).
+		Where("id = ? AND status = ?", id, "waiting").
+		Updates(map[string]interface{}{
+			"status":      "resumed",
+			"resumed_at":  resumedAt,
+			"resume_data": resumeData,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// Withdraw expires the waiting execution of a resume token, unless it was
+// resumed in the meantime. It reports whether it was withdrawn.
+func (r *WaitingExecutionRepository) Withdraw(ctx context.Context, token string) (bool, error) {
+	result := r.DB().WithContext(ctx).Model(&models.WaitingExecution{}).
+		Where("resume_token = ? AND status = ?", token, "waiting").
+		Update("status", "expired")
+	return result.RowsAffected > 0, result.Error
+}
+
 func (r *WaitingExecutionRepository) ExpireOld(ctx context.Context) (int64, error) {
 	result := r.DB().WithContext(ctx).Model(&models.WaitingExecution{}).
 		Where("status = ? AND timeout_at < ?", "waiting", time.Now()).
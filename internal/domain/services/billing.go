@@ -95,6 +95,7 @@ type PlanLimits struct {
 	WebhooksLimit       int    `json:"webhooks_limit"`
 	ExecutionTimeout    int    `json:"execution_timeout"`
 	MaxNodesPerWorkflow int    `json:"max_nodes_per_workflow"`
+	QueueWeight         int    `json:"queue_weight"`
 	RetentionDays       int    `json:"retention_days"`
 }
 
@@ -666,6 +667,7 @@ func (s *BillingService) GetPlanLimits(ctx context.Context, workspaceID uuid.UUI
 		WebhooksLimit:       plan.WebhooksLimit,
 		ExecutionTimeout:    plan.ExecutionTimeout,
 		MaxNodesPerWorkflow: plan.MaxNodesPerWorkflow,
+		QueueWeight:         plan.QueueWeight,
 		RetentionDays:       plan.RetentionDays,
 	}, nil
 }
@@ -13,6 +13,7 @@ import (
 var (
 	ErrExecutionNotFound = errors.New("execution not found")
 	ErrExecutionNotRunning = errors.New("execution is not running")
+	ErrExecutionNotPaused  = errors.New("execution is not paused")
 )
 
 type ExecutionService struct {
@@ -34,12 +35,18 @@ func NewExecutionService(
 }
 
 type CreateExecutionInput struct {
+	// ID is the pre-allocated execution ID; one is generated when it is nil
+	ID          uuid.UUID
 	WorkflowID  uuid.UUID
 	WorkspaceID uuid.UUID
 	TriggeredBy *uuid.UUID
 	TriggerType string
 	TriggerData models.JSON
 	InputData   models.JSON
+	// Priority (1-10) and Queue are the execution's priority and the queue
+	// it was enqueued on
+	Priority int
+	Queue    string
 }
 
 func (s *ExecutionService) Create(ctx context.Context, input CreateExecutionInput) (*models.Execution, error) {
@@ -49,6 +56,7 @@ func (s *ExecutionService) Create(ctx context.Context, input CreateExecutionInpu
 	}
 
 	execution := &models.Execution{
+		ID:              input.ID,
 		WorkflowID:      input.WorkflowID,
 		WorkspaceID:     input.WorkspaceID,
 		TriggeredBy:     input.TriggeredBy,
@@ -57,6 +65,8 @@ func (s *ExecutionService) Create(ctx context.Context, input CreateExecutionInpu
 		TriggerType:     input.TriggerType,
 		TriggerData:     input.TriggerData,
 		InputData:       input.InputData,
+		Priority:        input.Priority,
+		Queue:           input.Queue,
 	}
 
 	if err := s.executionRepo.Create(ctx, execution); err != nil {
@@ -95,8 +105,80 @@ func (s *ExecutionService) Complete(ctx context.Context, executionID uuid.UUID,
 	return s.executionRepo.UpdateStatus(ctx, executionID, models.ExecutionStatusCompleted)
 }
 
+// RecordQueue stores the priority and queue an execution was started with
+func (s *ExecutionService) RecordQueue(ctx context.Context, executionID uuid.UUID, priority int, queue string) error {
+	return s.executionRepo.SetQueue(ctx, executionID, priority, queue)
+}
+
+// Suspend marks an execution as waiting to be resumed
+func (s *ExecutionService) Suspend(ctx context.Context, executionID uuid.UUID) error {
+	return s.executionRepo.UpdateStatus(ctx, executionID, models.ExecutionStatusWaiting)
+}
+
+// Resume marks a waiting or paused execution as running again
+func (s *ExecutionService) Resume(ctx context.Context, executionID uuid.UUID) error {
+	return s.executionRepo.MarkResumed(ctx, executionID)
+}
+
+// Pause stores the state a running execution paused with. Executions
+// cancelled in the meantime stay cancelled.
+func (s *ExecutionService) Pause(ctx context.Context, executionID uuid.UUID, state models.JSON) error {
+	paused, err := s.executionRepo.MarkPaused(ctx, executionID, state)
+	if err != nil {
+		return err
+	}
+	if !paused {
+		return ErrExecutionNotRunning
+	}
+	return nil
+}
+
+// QueueResume queues a paused execution to continue from the state it paused
+// with
+func (s *ExecutionService) QueueResume(ctx context.Context, executionID uuid.UUID) error {
+	queued, err := s.executionRepo.TransitionStatus(ctx, executionID, models.ExecutionStatusPaused, models.ExecutionStatusQueued)
+	if err != nil {
+		return err
+	}
+	if !queued {
+		return ErrExecutionNotPaused
+	}
+	return nil
+}
+
+// UnqueueResume returns an execution to paused when its resume couldn't be
+// queued
+func (s *ExecutionService) UnqueueResume(ctx context.Context, executionID uuid.UUID) error {
+	_, err := s.executionRepo.TransitionStatus(ctx, executionID, models.ExecutionStatusQueued, models.ExecutionStatusPaused)
+	return err
+}
+
 func (s *ExecutionService) Fail(ctx context.Context, executionID uuid.UUID, errorMessage string, errorNodeID *string) error {
-	return s.executionRepo.SetError(ctx, executionID, errorMessage, errorNodeID)
+	return s.executionRepo.SetError(ctx, executionID, errorMessage, errorNodeID, nil)
+}
+
+// ClaimUsage reports whether the caller is the first to track the billing
+// usage of an execution
+func (s *ExecutionService) ClaimUsage(ctx context.Context, executionID uuid.UUID) (bool, error) {
+	return s.executionRepo.MarkUsageTracked(ctx, executionID)
+}
+
+// FailWithDetails fails an execution with a structured error (code, HTTP
+// status, hint) in addition to the message
+func (s *ExecutionService) FailWithDetails(ctx context.Context, executionID uuid.UUID, errorMessage string, errorNodeID *string, errorDetails models.JSON) error {
+	return s.executionRepo.SetError(ctx, executionID, errorMessage, errorNodeID, errorDetails)
+}
+
+// TimeOut fails an execution that exceeded its timeout with the distinct
+// timed out status
+func (s *ExecutionService) TimeOut(ctx context.Context, executionID uuid.UUID, errorMessage string, errorNodeID *string, errorDetails models.JSON) error {
+	return s.executionRepo.SetTimedOut(ctx, executionID, errorMessage, errorNodeID, errorDetails)
+}
+
+// DiscardNodeData drops the input and output data recorded for an
+// execution's nodes, for workflows that don't save successful executions
+func (s *ExecutionService) DiscardNodeData(ctx context.Context, executionID uuid.UUID) error {
+	return s.nodeExecutionRepo.ClearData(ctx, executionID)
 }
 
 func (s *ExecutionService) Cancel(ctx context.Context, executionID uuid.UUID) error {
@@ -105,7 +187,7 @@ func (s *ExecutionService) Cancel(ctx context.Context, executionID uuid.UUID) er
 		return err
 	}
 
-	if execution.Status != models.ExecutionStatusQueued && execution.Status != models.ExecutionStatusRunning {
+	if execution.Status != models.ExecutionStatusQueued && execution.Status != models.ExecutionStatusRunning && execution.Status != models.ExecutionStatusWaiting && execution.Status != models.ExecutionStatusPaused {
 		return ErrExecutionNotRunning
 	}
 
@@ -153,6 +235,34 @@ func (s *ExecutionService) SkipNodeExecution(ctx context.Context, nodeExecutionI
 	return s.nodeExecutionRepo.UpdateStatus(ctx, nodeExecutionID, models.NodeStatusSkipped)
 }
 
+// GetNodeOutputs returns the last successful output of every node of an
+// execution, keyed by node ID. Completed nodes whose output wasn't saved map
+// to nil.
+func (s *ExecutionService) GetNodeOutputs(ctx context.Context, executionID uuid.UUID) (map[string]map[string]interface{}, error) {
+	nodeExecs, err := s.nodeExecutionRepo.FindByExecutionID(ctx, executionID)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := make(map[string]map[string]interface{})
+	for _, ne := range nodeExecs {
+		if ne.Status != models.NodeStatusCompleted {
+			continue
+		}
+		if ne.OutputData != nil {
+			outputs[ne.NodeID] = ne.OutputData
+		} else if _, ok := outputs[ne.NodeID]; !ok {
+			outputs[ne.NodeID] = nil
+		}
+	}
+	return outputs, nil
+}
+
+// RecordNodeExecutions stores finished node executions in a single batch
+func (s *ExecutionService) RecordNodeExecutions(ctx context.Context, nodeExecs []models.NodeExecution) error {
+	return s.nodeExecutionRepo.CreateBatch(ctx, nodeExecs)
+}
+
 func (s *ExecutionService) Retry(ctx context.Context, executionID uuid.UUID, triggeredBy *uuid.UUID) (*models.Execution, error) {
 	original, err := s.executionRepo.FindByID(ctx, executionID)
 	if err != nil {
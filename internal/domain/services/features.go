@@ -490,18 +490,48 @@ func (s *EnvironmentVariableService) GetByWorkspace(ctx context.Context, workspa
 }
 
 func (s *EnvironmentVariableService) GetDecrypted(ctx context.Context, workspaceID uuid.UUID, environment *string) (map[string]string, error) {
+	vars, err := s.GetDecryptedVars(ctx, workspaceID, environment)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string, len(vars))
+	for name, v := range vars {
+		result[name] = v.Value
+	}
+	return result, nil
+}
+
+// DecryptedEnvVar is an environment variable with its plaintext value
+type DecryptedEnvVar struct {
+	Value    string
+	IsSecret bool
+}
+
+// GetDecryptedVars returns the workspace's variables by name. A variable of
+// the given environment takes precedence over one without an environment.
+// A variable that can't be decrypted fails the call with its name.
+func (s *EnvironmentVariableService) GetDecryptedVars(ctx context.Context, workspaceID uuid.UUID, environment *string) (map[string]DecryptedEnvVar, error) {
 	vars, err := s.GetByWorkspace(ctx, workspaceID, environment)
 	if err != nil {
 		return nil, err
 	}
 
-	result := make(map[string]string)
+	selected := make(map[string]models.EnvironmentVariable, len(vars))
 	for _, v := range vars {
+		if current, exists := selected[v.Name]; exists && current.Environment != nil {
+			continue
+		}
+		selected[v.Name] = v
+	}
+
+	result := make(map[string]DecryptedEnvVar, len(selected))
+	for name, v := range selected {
 		decrypted, err := s.encryptor.Decrypt(v.Value)
 		if err != nil {
-			continue
+			return nil, fmt.Errorf("failed to decrypt environment variable %s: %w", name, err)
 		}
-		result[v.Name] = decrypted
+		result[name] = DecryptedEnvVar{Value: decrypted, IsSecret: v.IsSecret}
 	}
 	return result, nil
 }
@@ -901,13 +931,18 @@ func (s *ExecutionReplayService) Replay(ctx context.Context, executionID uuid.UU
 		WorkflowID:  original.WorkflowID,
 		WorkspaceID: original.WorkspaceID,
 		TriggeredBy: triggeredBy,
-		TriggerType: "replay",
+		TriggerType: models.TriggerReplay,
 		TriggerData: original.TriggerData,
 		InputData:   original.InputData,
+		Priority:    original.Priority,
+		Queue:       original.Queue,
 	})
 }
 
-func (s *ExecutionReplayService) ReplayFromNode(ctx context.Context, executionID uuid.UUID, startNodeID string, triggeredBy *uuid.UUID) (*models.Execution, error) {
+// ReplayFromNode creates a partial execution that re-runs startNodeID and
+// its downstream nodes (up to stopNodeID when given), reusing the original
+// execution's outputs for everything upstream
+func (s *ExecutionReplayService) ReplayFromNode(ctx context.Context, executionID uuid.UUID, startNodeID, stopNodeID string, triggeredBy *uuid.UUID) (*models.Execution, error) {
 	original, err := s.execRepo.FindByID(ctx, executionID)
 	if err != nil {
 		return nil, err
@@ -918,14 +953,19 @@ func (s *ExecutionReplayService) ReplayFromNode(ctx context.Context, executionID
 		"replay_from":        startNodeID,
 		"original_execution": executionID.String(),
 	}
+	if stopNodeID != "" {
+		triggerData["stop_at"] = stopNodeID
+	}
 
 	return s.execService.Create(ctx, CreateExecutionInput{
 		WorkflowID:  original.WorkflowID,
 		WorkspaceID: original.WorkspaceID,
 		TriggeredBy: triggeredBy,
-		TriggerType: "partial_replay",
+		TriggerType: models.TriggerPartialReplay,
 		TriggerData: triggerData,
 		InputData:   original.InputData,
+		Priority:    original.Priority,
+		Queue:       original.Queue,
 	})
 }
 
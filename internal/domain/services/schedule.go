@@ -37,6 +37,7 @@ type CreateScheduleInput struct {
 	CronExpression string
 	Timezone       string
 	InputData      models.JSON
+	Priority       int // 1-10, the default priority when zero
 }
 
 func (s *ScheduleService) Create(ctx context.Context, input CreateScheduleInput) (*models.Schedule, error) {
@@ -55,6 +56,7 @@ func (s *ScheduleService) Create(ctx context.Context, input CreateScheduleInput)
 		Timezone:       input.Timezone,
 		IsActive:       true,
 		InputData:      input.InputData,
+		Priority:       input.Priority,
 		NextRunAt:      &nextRun,
 	}
 
@@ -87,6 +89,7 @@ type UpdateScheduleInput struct {
 	CronExpression *string
 	Timezone       *string
 	InputData      models.JSON
+	Priority       *int
 }
 
 func (s *ScheduleService) Update(ctx context.Context, scheduleID uuid.UUID, input UpdateScheduleInput) (*models.Schedule, error) {
@@ -110,6 +113,9 @@ func (s *ScheduleService) Update(ctx context.Context, scheduleID uuid.UUID, inpu
 	if input.InputData != nil {
 		schedule.InputData = input.InputData
 	}
+	if input.Priority != nil {
+		schedule.Priority = *input.Priority
+	}
 
 	if input.CronExpression != nil || input.Timezone != nil {
 		nextRun, err := s.calculateNextRun(schedule.CronExpression, schedule.Timezone)
@@ -190,6 +196,6 @@ func (s *ScheduleService) GetDueBatch(ctx context.Context, limit, offset int) ([
 	return s.scheduleRepo.FindDueBatch(ctx, limit, offset)
 }
 
-func (s *ScheduleService) GetDueByPriority(ctx context.Context, priority string) ([]models.Schedule, error) {
+func (s *ScheduleService) GetDueByPriority(ctx context.Context, priority int) ([]models.Schedule, error) {
 	return s.scheduleRepo.FindDueByPriority(ctx, priority)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -210,6 +211,20 @@ func generateRandomString(length int) (string, error) {
 	return hex.EncodeToString(bytes)[:length], nil
 }
 
+// Waiting execution resume types and statuses
+const (
+	WaitResumeTimer   = "timer"
+	WaitResumeWebhook = "webhook"
+
+	WaitStatusWaiting = "waiting"
+	WaitStatusResumed = "resumed"
+	WaitStatusExpired = "expired"
+)
+
+// ErrWaitNotWaiting is returned when resuming a wait that was resumed or
+// expired already
+var ErrWaitNotWaiting = errors.New("execution already resumed or expired")
+
 // WaitResumeManager handles wait/resume execution patterns
 type WaitResumeManager struct {
 	waitingRepo *repositories.WaitingExecutionRepository
@@ -241,34 +256,69 @@ type WaitInfo struct {
 	ExpiresAt   time.Time `json:"expires_at"`
 }
 
+// WaitInput contains parameters for suspending an execution
+type WaitInput struct {
+	ExecutionID   uuid.UUID
+	WorkflowID    uuid.UUID
+	WorkspaceID   uuid.UUID
+	NodeID        string
+	ResumeType    string // timer or webhook
+	ResumeAt      time.Time
+	ResumeToken   string // generated when empty
+	ExecutionData models.JSON
+	Priority      int // 1-10 priority the execution is resumed at
+}
+
 // CreateWaitForWebhook creates a waiting execution that can be resumed via webhook
 func (m *WaitResumeManager) CreateWaitForWebhook(ctx context.Context, input WaitForWebhookInput) (*WaitInfo, error) {
-	// Generate unique resume token
-	token, err := generateRandomString(32)
-	if err != nil {
-		return nil, err
-	}
-
-	// Generate webhook path
-	webhookPath := fmt.Sprintf("resume/%s", token)
-
 	timeout := input.Timeout
 	if timeout == 0 {
 		timeout = 24 * time.Hour // Default 24 hour timeout
 	}
-	expiresAt := time.Now().Add(timeout)
 
+	return m.CreateWait(ctx, WaitInput{
+		ExecutionID:   input.ExecutionID,
+		WorkflowID:    input.WorkflowID,
+		WorkspaceID:   input.WorkspaceID,
+		NodeID:        input.NodeID,
+		ResumeType:    WaitResumeWebhook,
+		ResumeAt:      time.Now().Add(timeout),
+		ExecutionData: input.ExecutionData,
+	})
+}
+
+// CreateWait stores a suspended execution. Timer waits are resumed by the
+// scheduler at ResumeAt; webhook waits through their resume URL, or by the
+// scheduler with {"timedOut": true} once ResumeAt has passed.
+func (m *WaitResumeManager) CreateWait(ctx context.Context, input WaitInput) (*WaitInfo, error) {
+	// Generate unique resume token unless the node already handed one out
+	token := input.ResumeToken
+	if token == "" {
+		var err error
+		token, err = generateRandomString(32)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	expiresAt := input.ResumeAt
 	waiting := &models.WaitingExecution{
 		ExecutionID:   input.ExecutionID,
 		WorkflowID:    input.WorkflowID,
 		WorkspaceID:   input.WorkspaceID,
 		NodeID:        input.NodeID,
 		ResumeToken:   token,
-		ResumeType:    "webhook",
-		WebhookPath:   &webhookPath,
+		ResumeType:    input.ResumeType,
 		TimeoutAt:     &expiresAt,
 		ExecutionData: input.ExecutionData,
-		Status:        "waiting",
+		Status:        WaitStatusWaiting,
+		Priority:      input.Priority,
+	}
+
+	var webhookPath string
+	if input.ResumeType == WaitResumeWebhook {
+		webhookPath = fmt.Sprintf("resume/%s", token)
+		waiting.WebhookPath = &webhookPath
 	}
 
 	if err := m.waitingRepo.Create(ctx, waiting); err != nil {
@@ -277,7 +327,7 @@ func (m *WaitResumeManager) CreateWaitForWebhook(ctx context.Context, input Wait
 
 	return &WaitInfo{
 		ResumeToken: token,
-		ResumeURL:   fmt.Sprintf("%s/webhook/%s", m.baseURL, webhookPath),
+		ResumeURL:   fmt.Sprintf("%s/api/v1/resume/%s", m.baseURL, token),
 		WebhookPath: webhookPath,
 		ExpiresAt:   expiresAt,
 	}, nil
@@ -290,26 +340,45 @@ func (m *WaitResumeManager) ResumeExecution(ctx context.Context, token string, d
 		return nil, fmt.Errorf("waiting execution not found: %w", err)
 	}
 
-	if waiting.Status != "waiting" {
-		return nil, fmt.Errorf("execution already resumed or expired")
+	if waiting.Status != WaitStatusWaiting {
+		return nil, ErrWaitNotWaiting
 	}
 
+	// The scheduler resumes it as timed out
 	if waiting.TimeoutAt != nil && time.Now().After(*waiting.TimeoutAt) {
-		waiting.Status = "expired"
-		_ = m.waitingRepo.Update(ctx, waiting)
 		return nil, fmt.Errorf("resume token has expired")
 	}
 
+	// Claim it, unless another resume call or the scheduler got there first
 	now := time.Now()
-	waiting.Status = "resumed"
+	claimed, err := m.waitingRepo.Claim(ctx, waiting.ID, now, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resume execution: %w", err)
+	}
+	if !claimed {
+		return nil, ErrWaitNotWaiting
+	}
+
+	waiting.Status = WaitStatusResumed
 	waiting.ResumedAt = &now
 	waiting.ResumeData = data
+	return waiting, nil
+}
 
-	if err := m.waitingRepo.Update(ctx, waiting); err != nil {
-		return nil, err
+// WithdrawWait expires a wait whose resume URL couldn't be handed out, so
+// the URL no longer resumes the execution. It reports false when the wait
+// was resumed already.
+func (m *WaitResumeManager) WithdrawWait(ctx context.Context, token string) (bool, error) {
+	withdrawn, err := m.waitingRepo.Withdraw(ctx, token)
+	if err != nil {
+		return false, fmt.Errorf("failed to withdraw wait: %w", err)
 	}
+	return withdrawn, nil
+}
 
-	return waiting, nil
+// GetWaitingByID retrieves a waiting execution by ID
+func (m *WaitResumeManager) GetWaitingByID(ctx context.Context, id uuid.UUID) (*models.WaitingExecution, error) {
+	return m.waitingRepo.FindByID(ctx, id)
 }
 
 // GetWaitingExecution retrieves a waiting execution by token
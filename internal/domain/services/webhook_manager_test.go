@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/linkflow-ai/linkflow/internal/domain/models"
+	"github.com/linkflow-ai/linkflow/internal/domain/repositories"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// testDB connects to the Postgres database CI provides through DATABASE_HOST
+// and friends, and skips the test without one
+func testDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	host := os.Getenv("DATABASE_HOST")
+	if host == "" {
+		t.Skip("DATABASE_HOST is not set")
+	}
+	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+		host, os.Getenv("DATABASE_PORT"), os.Getenv("DATABASE_USER"),
+		os.Getenv("DATABASE_PASSWORD"), os.Getenv("DATABASE_NAME"))
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		Logger:                                   logger.Default.LogMode(logger.Silent),
+		DisableForeignKeyConstraintWhenMigrating: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to connect to the test database: %v", err)
+	}
+	if err := db.AutoMigrate(&models.WaitingExecution{}); err != nil {
+		t.Fatalf("failed to migrate the test database: %v", err)
+	}
+	return db
+}
+
+func TestResumeExecutionClaimsOnce(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	mgr := NewWaitResumeManager(repositories.NewWaitingExecutionRepository(db), "http://localhost:8080")
+
+	info, err := mgr.CreateWait(ctx, WaitInput{
+		ExecutionID: uuid.New(),
+		WorkflowID:  uuid.New(),
+		WorkspaceID: uuid.New(),
+		NodeID:      "approval",
+		ResumeType:  WaitResumeWebhook,
+		ResumeAt:    time.Now().Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("failed to create wait: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Where("resume_token = ?", info.ResumeToken).Delete(&models.WaitingExecution{})
+	})
+
+	// An approve and a reject click arriving together
+	decisions := []string{"approved", "rejected"}
+	errs := make([]error, len(decisions))
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i, decision := range decisions {
+		wg.Add(1)
+		go func(i int, decision string) {
+			defer wg.Done()
+			<-start
+			_, errs[i] = mgr.ResumeExecution(ctx, info.ResumeToken, models.JSON{"decision": decision})
+		}(i, decision)
+	}
+	close(start)
+	wg.Wait()
+
+	winner := ""
+	for i, err := range errs {
+		switch {
+		case err == nil:
+			if winner != "" {
+				t.Fatalf("both %s and %s resumed the execution", winner, decisions[i])
+			}
+			winner = decisions[i]
+		case !errors.Is(err, ErrWaitNotWaiting):
+			t.Fatalf("resume failed: %v", err)
+		}
+	}
+	if winner == "" {
+		t.Fatal("no resume succeeded")
+	}
+
+	waiting, err := mgr.GetWaitingExecution(ctx, info.ResumeToken)
+	if err != nil {
+		t.Fatalf("failed to load wait: %v", err)
+	}
+	if waiting.Status != WaitStatusResumed {
+		t.Errorf("status %q, want %q", waiting.Status, WaitStatusResumed)
+	}
+	if decision := waiting.ResumeData["decision"]; decision != winner {
+		t.Errorf("stored decision %v, want the winner's %s", decision, winner)
+	}
+}
@@ -29,6 +29,7 @@ type WorkflowService struct {
 	workflowRepo        *repositories.WorkflowRepository
 	versionRepo         *repositories.WorkflowVersionRepository
 	webhookEndpointRepo *repositories.WebhookEndpointRepository
+	pinnedDataRepo      *repositories.PinnedDataRepository
 }
 
 // NewWorkflowService creates a new WorkflowService with required repositories.
@@ -50,6 +51,29 @@ func (s *WorkflowService) SetWebhookEndpointRepo(repo *repositories.WebhookEndpo
 	s.webhookEndpointRepo = repo
 }
 
+// SetPinnedDataRepo sets the pinned data repository (optional dependency)
+func (s *WorkflowService) SetPinnedDataRepo(repo *repositories.PinnedDataRepository) {
+	s.pinnedDataRepo = repo
+}
+
+// GetPinnedData returns the pinned output of each node of a workflow, keyed by node ID
+func (s *WorkflowService) GetPinnedData(ctx context.Context, workflowID uuid.UUID) (map[string]map[string]interface{}, error) {
+	if s.pinnedDataRepo == nil {
+		return nil, nil
+	}
+
+	pinnedList, err := s.pinnedDataRepo.FindByWorkflow(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	pinned := make(map[string]map[string]interface{}, len(pinnedList))
+	for _, p := range pinnedList {
+		pinned[p.NodeID] = p.Data
+	}
+	return pinned, nil
+}
+
 type CreateWorkflowInput struct {
 	WorkspaceID uuid.UUID
 	CreatedBy   uuid.UUID
@@ -331,6 +355,7 @@ type WebhookEndpoint struct {
 	Secret          string
 	ResponseMode    string // "immediate" or "wait"
 	ResponseTimeout int    // timeout in seconds for wait mode
+	Priority        int    // 1-10 priority of the executions it starts, 0 for the default
 }
 
 func (s *WorkflowService) GetWebhookByEndpoint(ctx context.Context, endpointID string) (*WebhookEndpoint, error) {
@@ -350,6 +375,7 @@ func (s *WorkflowService) GetWebhookByEndpoint(ctx context.Context, endpointID s
 					EndpointID:   endpointID,
 					Secret:       secret,
 					ResponseMode: WebhookResponseModeImmediate,
+					Priority:     webhookPriority(workflow.Settings),
 				}, nil
 			}
 		}
@@ -390,5 +416,19 @@ func (s *WorkflowService) GetWebhookByEndpoint(ctx context.Context, endpointID s
 		EndpointID:   endpointID,
 		Secret:       secret,
 		ResponseMode: responseMode,
+		Priority:     webhookPriority(settings),
 	}, nil
 }
+
+// webhookPriority returns the execution priority set in a workflow's webhook
+// settings
+func webhookPriority(settings models.JSON) int {
+	webhookSettings, ok := settings["webhook"].(map[string]interface{})
+	if !ok {
+		return 0
+	}
+	if priority, ok := webhookSettings["priority"].(float64); ok {
+		return int(priority)
+	}
+	return 0
+}
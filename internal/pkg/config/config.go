@@ -23,6 +23,7 @@ type Config struct {
 
 type FeaturesConfig struct {
 	WebhookStream WebhookStreamConfig
+	FairQueue     FairQueueConfig
 }
 
 type WebhookStreamConfig struct {
@@ -35,6 +36,12 @@ type WebhookStreamConfig struct {
 	ConsumerCount int   // Number of consumer goroutines (default: 2)
 }
 
+// FairQueueConfig configures fair scheduling of executions across workspaces
+type FairQueueConfig struct {
+	Enabled bool
+	Backlog int // Max pending tasks per worker queue fed ahead of the workers (default: 50)
+}
+
 type AppConfig struct {
 	Name                   string
 	Environment            string
@@ -244,6 +251,10 @@ func Load() (*Config, error) {
 	cfg.Features.WebhookStream.StaleTimeout = viper.GetInt("features.webhook_stream.stale_timeout")
 	cfg.Features.WebhookStream.ConsumerCount = viper.GetInt("features.webhook_stream.consumer_count")
 
+	// Features - Fair Queue
+	cfg.Features.FairQueue.Enabled = viper.GetBool("features.fair_queue.enabled")
+	cfg.Features.FairQueue.Backlog = viper.GetInt("features.fair_queue.backlog")
+
 	return &cfg, nil
 }
 
@@ -302,4 +313,8 @@ func setDefaults() {
 	viper.SetDefault("features.webhook_stream.max_retries", 3)
 	viper.SetDefault("features.webhook_stream.stale_timeout", 300)
 	viper.SetDefault("features.webhook_stream.consumer_count", 2)
+
+	// Features - Fair Queue defaults
+	viper.SetDefault("features.fair_queue.enabled", true)
+	viper.SetDefault("features.fair_queue.backlog", 50)
 }
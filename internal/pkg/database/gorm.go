@@ -132,10 +132,26 @@ func AutoMigrate(db *gorm.DB) error {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 
+	if err := migrateExecutionStatuses(db); err != nil {
+		return err
+	}
+
 	log.Info().Msg("Database migrations completed")
 	return nil
 }
 
+// migrateExecutionStatuses renames the status of executions that timed out
+// from "timeout" to "timed_out"
+func migrateExecutionStatuses(db *gorm.DB) error {
+	err := db.Model(&models.Execution{}).
+		Where("status = ?", "timeout").
+		Update("status", models.ExecutionStatusTimedOut).Error
+	if err != nil {
+		return fmt.Errorf("failed to migrate execution statuses: %w", err)
+	}
+	return nil
+}
+
 func SeedPlans(db *gorm.DB) error {
 	plans := models.DefaultPlans()
 
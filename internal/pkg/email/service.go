@@ -72,6 +72,7 @@ func (s *Service) loadBuiltinTemplates() {
 		"execution_failed":     executionFailedTemplate,
 		"usage_warning":        usageWarningTemplate,
 		"billing_alert":        billingAlertTemplate,
+		"approval_request":     approvalRequestTemplate,
 	}
 
 	for name, content := range templates {
@@ -361,7 +362,6 @@ func (s *Service) SendWorkspaceInvitation(ctx context.Context, to, inviterName,
 
 func (s *Service) SendExecutionFailed(ctx context.Context, to, workflowName, executionID, errorMsg string) error {
 	return s.SendTemplate(ctx, "execution_failed", []string{to}, fmt.Sprintf("Workflow '%s' failed", workflowName), TemplateData{
-		"WorkflowName": workflowName,
 		"ExecutionID":  executionID,
 		"ErrorMessage": errorMsg,
 		"AppName":      "LinkFlow",
@@ -380,6 +380,19 @@ func (s *Service) SendUsageWarning(ctx context.Context, to, workspaceName string
 	})
 }
 
+// SendApprovalRequest asks a recipient to approve or reject a waiting
+// workflow execution
+func (s *Service) SendApprovalRequest(ctx context.Context, to, title, message, approveURL, rejectURL string, expiresAt time.Time) error {
+	return s.SendTemplate(ctx, "approval_request", []string{to}, title, TemplateData{
+		"Title":      title,
+		"Message":    message,
+		"ApproveURL": approveURL,
+		"RejectURL":  rejectURL,
+		"ExpiresAt":  expiresAt.Format(time.RFC1123),
+		"AppName":    "LinkFlow",
+	})
+}
+
 func (s *Service) SendBillingAlert(ctx context.Context, to string, alertType string, data TemplateData) error {
 	subjects := map[string]string{
 		"payment_failed":      "Payment failed",
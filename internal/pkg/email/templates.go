@@ -319,3 +319,44 @@ const billingAlertTemplate = `
 </body>
 </html>
 `
+
+const approvalRequestTemplate = `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="utf-8">
+    <style>
+        body { font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; line-height: 1.6; color: #333; margin: 0; padding: 0; background-color: #f5f5f5; }
+        .container { max-width: 600px; margin: 0 auto; background: #ffffff; }
+        .header { background: #4F46E5; padding: 30px; text-align: center; }
+        .header h1 { color: #ffffff; margin: 0; font-size: 24px; }
+        .content { padding: 40px 30px; }
+        .button { display: inline-block; padding: 14px 30px; background: #16A34A; color: #ffffff; text-decoration: none; border-radius: 6px; font-weight: 600; margin: 20px 10px; }
+        .button-reject { background: #DC2626; }
+        .footer { padding: 30px; text-align: center; color: #666; font-size: 14px; background: #f9f9f9; }
+        .message-box { background: #F3F4F6; padding: 20px; border-radius: 8px; margin: 20px 0; white-space: pre-wrap; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>{{.Title}}</h1>
+        </div>
+        <div class="content">
+            <p>A workflow execution is waiting for your approval.</p>
+            {{if .Message}}
+            <div class="message-box">{{.Message}}</div>
+            {{end}}
+            <p style="text-align: center;">
+                <a href="{{.ApproveURL}}" class="button">Approve</a>
+                <a href="{{.RejectURL}}" class="button button-reject">Reject</a>
+            </p>
+            <p>This request expires on {{.ExpiresAt}}.</p>
+        </div>
+        <div class="footer">
+            <p>© {{.AppName}}. All rights reserved.</p>
+        </div>
+    </div>
+</body>
+</html>
+`
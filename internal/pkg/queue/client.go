@@ -27,6 +27,8 @@ const (
 
 type Client struct {
 	client *asynq.Client
+	opts   asynq.RedisClientOpt
+	fair   *FairQueue
 }
 
 func NewClient(cfg *config.RedisConfig) *Client {
@@ -43,7 +45,18 @@ func NewClient(cfg *config.RedisConfig) *Client {
 	}
 
 	client := asynq.NewClient(opts)
-	return &Client{client: client}
+	return &Client{client: client, opts: opts}
+}
+
+// SetFairQueue stages executions in the fair queue instead of enqueueing
+// them directly
+func (c *Client) SetFairQueue(fair *FairQueue) {
+	c.fair = fair
+}
+
+// FairQueue returns the fair queue executions are staged in, if any
+func (c *Client) FairQueue() *FairQueue {
+	return c.fair
 }
 
 func (c *Client) Close() error {
@@ -51,6 +64,19 @@ func (c *Client) Close() error {
 }
 
 // Workflow Execution
+
+// MaxExecutionTimeout caps the effective timeout of an execution; it's the
+// default timeout of an execution record
+const MaxExecutionTimeout = time.Hour
+
+// executionTaskTimeout is when the queue gives up on an execution task. It
+// leaves an execution that hits its timeout the time to be stopped and
+// stored as timed out by the worker.
+const executionTaskTimeout = MaxExecutionTimeout + 5*time.Minute
+
+// WorkflowExecutionPayload starts or resumes an execution. ExecutionID is
+// the execution record created by whoever enqueues it; payloads without one
+// get a record on their first delivery.
 type WorkflowExecutionPayload struct {
 	WorkflowID  uuid.UUID   `json:"workflow_id"`
 	WorkspaceID uuid.UUID   `json:"workspace_id"`
@@ -59,25 +85,51 @@ type WorkflowExecutionPayload struct {
 	TriggerType string      `json:"trigger_type"`
 	TriggerData models.JSON `json:"trigger_data,omitempty"`
 	InputData   models.JSON `json:"input_data,omitempty"`
+	DryRun      bool        `json:"dry_run,omitempty"`
+	// WaitingID resumes the suspended execution ExecutionID from this wait
+	WaitingID uuid.UUID `json:"waiting_id,omitempty"`
+	// Priority (1-10) decides the queue the execution runs from. It defaults
+	// by trigger type when not set.
+	Priority int `json:"priority,omitempty"`
+	// TaskID is the ID of the queue task, the same for every delivery of it.
+	// It is set by the worker.
+	TaskID string `json:"-"`
 }
 
+// withPriority sets the payload's effective priority
+func (p WorkflowExecutionPayload) withPriority() WorkflowExecutionPayload {
+	p.Priority = EffectivePriority(p.TriggerType, p.Priority)
+	return p
+}
+
+// EnqueueWorkflowExecution queues an execution on the queue of its priority.
+// With a fair queue, it's staged in its workspace's sub-queue first.
 func (c *Client) EnqueueWorkflowExecution(ctx context.Context, payload WorkflowExecutionPayload) (*asynq.TaskInfo, error) {
+	if c.fair != nil && payload.WorkspaceID != uuid.Nil {
+		return c.fair.stage(ctx, payload)
+	}
+	return c.enqueueWorkflowExecution(ctx, payload)
+}
+
+func (c *Client) enqueueWorkflowExecution(ctx context.Context, payload WorkflowExecutionPayload, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	payload = payload.withPriority()
 	data, err := json.Marshal(payload)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal payload: %w", err)
 	}
 
-	task := asynq.NewTask(TypeWorkflowExecution, data,
-		asynq.Queue(QueueDefault),
+	task := asynq.NewTask(TypeWorkflowExecution, data, append([]asynq.Option{
+		asynq.Queue(QueueForPriority(payload.Priority)),
 		asynq.MaxRetry(3),
-		asynq.Timeout(5*time.Minute),
+		asynq.Timeout(executionTaskTimeout),
 		asynq.Retention(24*time.Hour),
-	)
+	}, opts...)...)
 
 	return c.client.EnqueueContext(ctx, task)
 }
 
 func (c *Client) EnqueuePriorityWorkflowExecution(ctx context.Context, payload WorkflowExecutionPayload) (*asynq.TaskInfo, error) {
+	payload.Priority = PriorityMax
 	data, err := json.Marshal(payload)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal payload: %w", err)
@@ -86,23 +138,26 @@ func (c *Client) EnqueuePriorityWorkflowExecution(ctx context.Context, payload W
 	task := asynq.NewTask(TypeWorkflowExecution, data,
 		asynq.Queue(QueueCritical),
 		asynq.MaxRetry(3),
-		asynq.Timeout(5*time.Minute),
+		asynq.Timeout(executionTaskTimeout),
 		asynq.Retention(24*time.Hour),
 	)
 
 	return c.client.EnqueueContext(ctx, task)
 }
 
+// EnqueueDelayedWorkflowExecution queues an execution on the queue of its
+// priority, to run after delay
 func (c *Client) EnqueueDelayedWorkflowExecution(ctx context.Context, payload WorkflowExecutionPayload, delay time.Duration) (*asynq.TaskInfo, error) {
+	payload = payload.withPriority()
 	data, err := json.Marshal(payload)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal payload: %w", err)
 	}
 
 	task := asynq.NewTask(TypeWorkflowExecution, data,
-		asynq.Queue(QueueLow),
+		asynq.Queue(QueueForPriority(payload.Priority)),
 		asynq.MaxRetry(3),
-		asynq.Timeout(5*time.Minute),
+		asynq.Timeout(executionTaskTimeout),
 		asynq.Retention(24*time.Hour),
 		asynq.ProcessIn(delay),
 	)
@@ -0,0 +1,394 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// fairFeedInterval is how often the feeder tops up the worker queues
+	fairFeedInterval = 200 * time.Millisecond
+	// fairWeightTTL is how long a workspace's weight is cached
+	fairWeightTTL = time.Minute
+	// DefaultFairBacklog is the default number of pending tasks per worker
+	// queue fed ahead of the workers
+	DefaultFairBacklog = 50
+)
+
+// fairQueues are the worker queues executions are staged for
+var fairQueues = []string{QueueCritical, QueueDefault, QueueLow}
+
+// WeightFunc returns a workspace's share of the worker queues relative to
+// other workspaces. Weights below 1 count as 1.
+type WeightFunc func(ctx context.Context, workspaceID uuid.UUID) int
+
+// stageScript appends a task to a workspace's sub-queue. Workspaces joining
+// the queue start at the current virtual time, so they get no credit for the
+// time they were idle.
+//
+// KEYS[1] workspace sub-queue, KEYS[2] workspaces, KEYS[3] virtual time,
+// ARGV[1] task, ARGV[2] workspace ID, ARGV[3] RPUSH or LPUSH
+var stageScript = redis.NewScript(`
+redis.call(ARGV[3], KEYS[1], ARGV[1])
+local vtime = redis.call('GET', KEYS[3]) or '0'
+redis.call('ZADD', KEYS[2], 'NX', vtime, ARGV[2])
+return 1
+`)
+
+// restageScript moves a task that failed to enqueue from the processing list
+// back to the front of its workspace's sub-queue.
+//
+// KEYS[1] workspace sub-queue, KEYS[2] workspaces, KEYS[3] virtual time,
+// KEYS[4] processing list, ARGV[1] task, ARGV[2] workspace ID
+var restageScript = redis.NewScript(`
+redis.call('LREM', KEYS[4], 1, ARGV[1])
+redis.call('LPUSH', KEYS[1], ARGV[1])
+local vtime = redis.call('GET', KEYS[3]) or '0'
+redis.call('ZADD', KEYS[2], 'NX', vtime, ARGV[2])
+return 1
+`)
+
+// popScript moves the next task of a workspace's sub-queue to the processing
+// list, where it stays until asynq accepted it, and moves the workspace back
+// by the cost of the task, the inverse of its weight.
+//
+// KEYS[1] workspace sub-queue, KEYS[2] workspaces, KEYS[3] virtual time,
+// KEYS[4] processing list, ARGV[1] workspace ID, ARGV[2] cost
+var popScript = redis.NewScript(`
+local task = redis.call('LMOVE', KEYS[1], KEYS[4], 'LEFT', 'RIGHT')
+if not task then
+	redis.call('ZREM', KEYS[2], ARGV[1])
+	return false
+end
+local score = redis.call('ZSCORE', KEYS[2], ARGV[1])
+if score and tonumber(score) > tonumber(redis.call('GET', KEYS[3]) or '0') then
+	redis.call('SET', KEYS[3], score)
+end
+if redis.call('LLEN', KEYS[1]) == 0 then
+	redis.call('ZREM', KEYS[2], ARGV[1])
+else
+	redis.call('ZINCRBY', KEYS[2], ARGV[2], ARGV[1])
+end
+return task
+`)
+
+// stagedTask is a workflow execution waiting in a workspace sub-queue
+type stagedTask struct {
+	ID      string                   `json:"id"`
+	Payload WorkflowExecutionPayload `json:"payload"`
+}
+
+// WorkspaceDepth is the number of executions a workspace has staged on a
+// worker queue
+type WorkspaceDepth struct {
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	Queue       string    `json:"queue"`
+	Depth       int64     `json:"depth"`
+}
+
+type cachedWeight struct {
+	weight  int
+	expires time.Time
+}
+
+// FairQueue stages workflow executions in per-workspace sub-queues and feeds
+// them to the worker queues by weighted fair queueing, so a workspace with a
+// large backlog doesn't starve the others. Only a bounded backlog is kept in
+// the worker queues; the order of everything else is decided when it's fed.
+type FairQueue struct {
+	redis   *redis.Client
+	client  *Client
+	weights WeightFunc
+
+	mu          sync.Mutex
+	weightCache map[uuid.UUID]cachedWeight
+}
+
+// NewFairQueue creates a fair queue feeding client's worker queues
+func NewFairQueue(redis *redis.Client, client *Client) *FairQueue {
+	return &FairQueue{
+		redis:       redis,
+		client:      client,
+		weightCache: make(map[uuid.UUID]cachedWeight),
+	}
+}
+
+// SetWeightFunc sets the function weighting workspaces. Without it, all
+// workspaces get an equal share.
+func (f *FairQueue) SetWeightFunc(weights WeightFunc) {
+	f.weights = weights
+}
+
+func fairWorkspacesKey(queue string) string {
+	return fmt.Sprintf("fairqueue:%s:workspaces", queue)
+}
+
+func fairVirtualTimeKey(queue string) string {
+	return fmt.Sprintf("fairqueue:%s:vtime", queue)
+}
+
+func fairSubQueueKey(queue string, workspaceID string) string {
+	return fmt.Sprintf("fairqueue:%s:ws:%s", queue, workspaceID)
+}
+
+// fairProcessingKey holds the tasks taken from the sub-queues of a queue
+// that asynq hasn't accepted yet
+func fairProcessingKey(queue string) string {
+	return fmt.Sprintf("fairqueue:%s:processing", queue)
+}
+
+// stage adds an execution to its workspace's sub-queue of the queue of its
+// priority. The returned task info is that of the task it will be fed as.
+func (f *FairQueue) stage(ctx context.Context, payload WorkflowExecutionPayload) (*asynq.TaskInfo, error) {
+	payload = payload.withPriority()
+	queue := QueueForPriority(payload.Priority)
+
+	task := stagedTask{ID: uuid.NewString(), Payload: payload}
+	data, err := json.Marshal(task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	err = stageScript.Run(ctx, f.redis,
+		[]string{fairSubQueueKey(queue, payload.WorkspaceID.String()), fairWorkspacesKey(queue), fairVirtualTimeKey(queue)},
+		data, payload.WorkspaceID.String(), "RPUSH",
+	).Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stage execution: %w", err)
+	}
+
+	return &asynq.TaskInfo{
+		ID:       task.ID,
+		Queue:    queue,
+		Type:     TypeWorkflowExecution,
+		State:    asynq.TaskStatePending,
+		MaxRetry: 3,
+	}, nil
+}
+
+// restage puts a task taken for processing back at the front of its
+// workspace's sub-queue
+func (f *FairQueue) restage(ctx context.Context, queue, workspaceID, data string) error {
+	return restageScript.Run(ctx, f.redis,
+		[]string{fairSubQueueKey(queue, workspaceID), fairWorkspacesKey(queue), fairVirtualTimeKey(queue), fairProcessingKey(queue)},
+		data, workspaceID,
+	).Err()
+}
+
+// Run feeds the worker queues until ctx is done, keeping up to backlog
+// pending tasks in each. Every worker may run it; the backlog is then
+// exceeded by at most one feed per worker.
+func (f *FairQueue) Run(ctx context.Context, backlog int) {
+	if backlog <= 0 {
+		backlog = DefaultFairBacklog
+	}
+
+	inspector := asynq.NewInspector(f.client.opts)
+	defer inspector.Close()
+
+	log.Info().Int("backlog", backlog).Msg("Fair queue feeder started")
+
+	for _, queue := range fairQueues {
+		if err := f.recover(ctx, queue); err != nil {
+			log.Warn().Err(err).Str("queue", queue).Msg("Failed to recover staged executions")
+		}
+	}
+
+	ticker := time.NewTicker(fairFeedInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, queue := range fairQueues {
+				if err := f.feed(ctx, inspector, queue, backlog); err != nil && ctx.Err() == nil {
+					log.Warn().Err(err).Str("queue", queue).Msg("Failed to feed worker queue")
+				}
+			}
+		}
+	}
+}
+
+// feed tops up a worker queue to backlog pending tasks
+func (f *FairQueue) feed(ctx context.Context, inspector *asynq.Inspector, queue string, backlog int) error {
+	pending, err := pendingTasks(inspector, queue)
+	if err != nil {
+		return err
+	}
+
+	for n := backlog - pending; n > 0; n-- {
+		fed, err := f.feedNext(ctx, queue)
+		if err != nil || !fed {
+			return err
+		}
+	}
+	return nil
+}
+
+func pendingTasks(inspector *asynq.Inspector, queue string) (int, error) {
+	queues, err := inspector.Queues()
+	if err != nil {
+		return 0, err
+	}
+	for _, name := range queues {
+		if name == queue {
+			info, err := inspector.GetQueueInfo(queue)
+			if err != nil {
+				return 0, err
+			}
+			return info.Pending, nil
+		}
+	}
+	// The queue doesn't exist until its first task
+	return 0, nil
+}
+
+// feedNext enqueues the next task of the workspace furthest behind its share.
+// It reports whether any workspace had a task staged.
+func (f *FairQueue) feedNext(ctx context.Context, queue string) (bool, error) {
+	data, staged, err := f.takeNext(ctx, queue)
+	if err != nil || data == "" {
+		return staged, err
+	}
+
+	if err := f.enqueueStaged(ctx, queue, data); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// takeNext moves the next task of the workspace furthest behind its share to
+// the processing list. It reports whether any workspace had a task staged;
+// the task is empty when another feeder took it first.
+func (f *FairQueue) takeNext(ctx context.Context, queue string) (string, bool, error) {
+	next, err := f.redis.ZRange(ctx, fairWorkspacesKey(queue), 0, 0).Result()
+	if err != nil {
+		return "", false, err
+	}
+	if len(next) == 0 {
+		return "", false, nil
+	}
+	workspaceID := next[0]
+
+	cost := 1 / float64(f.weight(ctx, workspaceID))
+	data, err := popScript.Run(ctx, f.redis,
+		[]string{fairSubQueueKey(queue, workspaceID), fairWorkspacesKey(queue), fairVirtualTimeKey(queue), fairProcessingKey(queue)},
+		workspaceID, cost,
+	).Text()
+	if errors.Is(err, redis.Nil) {
+		// Another feeder took the last task
+		return "", true, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return data, true, nil
+}
+
+// enqueueStaged enqueues a task from the processing list and removes it
+// from the list once asynq accepted it. Tasks asynq refused go back to their
+// sub-queue; tasks that can't be put back stay in the processing list until
+// the next feeder starts.
+func (f *FairQueue) enqueueStaged(ctx context.Context, queue, data string) error {
+	var task stagedTask
+	if err := json.Unmarshal([]byte(data), &task); err != nil {
+		log.Error().Err(err).Str("queue", queue).Msg("Dropping malformed staged execution")
+		return f.redis.LRem(ctx, fairProcessingKey(queue), 1, data).Err()
+	}
+
+	// The task ID makes enqueueing a task twice a no-op
+	_, err := f.client.enqueueWorkflowExecution(ctx, task.Payload, asynq.TaskID(task.ID))
+	if err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+		if restageErr := f.restage(ctx, queue, task.Payload.WorkspaceID.String(), data); restageErr != nil {
+			log.Error().Err(restageErr).
+				Str("execution_id", task.Payload.ExecutionID.String()).
+				Msg("Failed to restage execution, leaving it for recovery")
+		}
+		return fmt.Errorf("failed to enqueue staged execution: %w", err)
+	}
+
+	if err := f.redis.LRem(ctx, fairProcessingKey(queue), 1, data).Err(); err != nil {
+		return fmt.Errorf("failed to remove fed execution: %w", err)
+	}
+	return nil
+}
+
+// recover enqueues the tasks a feeder took for processing but didn't get
+// to enqueue or remove, e.g. because its process died
+func (f *FairQueue) recover(ctx context.Context, queue string) error {
+	leftovers, err := f.redis.LRange(ctx, fairProcessingKey(queue), 0, -1).Result()
+	if err != nil {
+		return fmt.Errorf("failed to list processing executions: %w", err)
+	}
+
+	for _, data := range leftovers {
+		if err := f.enqueueStaged(ctx, queue, data); err != nil {
+			return err
+		}
+	}
+	if len(leftovers) > 0 {
+		log.Info().Str("queue", queue).Int("count", len(leftovers)).Msg("Recovered staged executions")
+	}
+	return nil
+}
+
+// weight returns a workspace's cached weight
+func (f *FairQueue) weight(ctx context.Context, workspaceID string) int {
+	id, err := uuid.Parse(workspaceID)
+	if err != nil || f.weights == nil {
+		return 1
+	}
+
+	f.mu.Lock()
+	cached, ok := f.weightCache[id]
+	f.mu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.weight
+	}
+
+	weight := f.weights(ctx, id)
+	if weight < 1 {
+		weight = 1
+	}
+
+	f.mu.Lock()
+	f.weightCache[id] = cachedWeight{weight: weight, expires: time.Now().Add(fairWeightTTL)}
+	f.mu.Unlock()
+	return weight
+}
+
+// Depths returns the number of executions each workspace has staged, by
+// worker queue
+func (f *FairQueue) Depths(ctx context.Context) ([]WorkspaceDepth, error) {
+	var depths []WorkspaceDepth
+	for _, queue := range fairQueues {
+		workspaces, err := f.redis.ZRange(ctx, fairWorkspacesKey(queue), 0, -1).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list workspaces: %w", err)
+		}
+
+		for _, workspaceID := range workspaces {
+			depth, err := f.redis.LLen(ctx, fairSubQueueKey(queue, workspaceID)).Result()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get queue depth: %w", err)
+			}
+			id, err := uuid.Parse(workspaceID)
+			if err != nil || depth == 0 {
+				continue
+			}
+			depths = append(depths, WorkspaceDepth{WorkspaceID: id, Queue: queue, Depth: depth})
+		}
+	}
+	return depths, nil
+}
@@ -0,0 +1,130 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// testRedis connects to the Redis CI provides through REDIS_HOST, and skips
+// the test without one. Tests use database 15 and remove the keys of the
+// fair queue they feed.
+func testRedis(t *testing.T, workspaces ...uuid.UUID) *redis.Client {
+	t.Helper()
+
+	host := os.Getenv("REDIS_HOST")
+	if host == "" {
+		t.Skip("REDIS_HOST is not set")
+	}
+	port := os.Getenv("REDIS_PORT")
+	if port == "" {
+		port = "6379"
+	}
+	client := redis.NewClient(&redis.Options{Addr: fmt.Sprintf("%s:%s", host, port), DB: 15})
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		t.Fatalf("failed to connect to Redis: %v", err)
+	}
+
+	queue := QueueForPriority(PriorityDefault)
+	keys := []string{fairWorkspacesKey(queue), fairVirtualTimeKey(queue), fairProcessingKey(queue)}
+	for _, id := range workspaces {
+		keys = append(keys, fairSubQueueKey(queue, id.String()))
+	}
+	client.Del(context.Background(), keys...)
+	t.Cleanup(func() {
+		client.Del(context.Background(), keys...)
+		client.Close()
+	})
+	return client
+}
+
+func stageExecutions(t *testing.T, f *FairQueue, workspaceID uuid.UUID, n int) {
+	t.Helper()
+
+	for i := 0; i < n; i++ {
+		_, err := f.stage(context.Background(), WorkflowExecutionPayload{
+			WorkflowID:  uuid.New(),
+			WorkspaceID: workspaceID,
+			ExecutionID: uuid.New(),
+		})
+		if err != nil {
+			t.Fatalf("failed to stage execution: %v", err)
+		}
+	}
+}
+
+// takeExecutions takes n tasks the way the feeder does and returns the
+// workspace of each
+func takeExecutions(t *testing.T, f *FairQueue, n int) []uuid.UUID {
+	t.Helper()
+
+	var taken []uuid.UUID
+	for i := 0; i < n; i++ {
+		data, _, err := f.takeNext(context.Background(), QueueForPriority(PriorityDefault))
+		if err != nil {
+			t.Fatalf("failed to take execution: %v", err)
+		}
+		if data == "" {
+			t.Fatalf("ran out of executions after %d", i)
+		}
+		var task stagedTask
+		if err := json.Unmarshal([]byte(data), &task); err != nil {
+			t.Fatalf("malformed staged execution: %v", err)
+		}
+		taken = append(taken, task.Payload.WorkspaceID)
+	}
+	return taken
+}
+
+func count(ids []uuid.UUID, id uuid.UUID) int {
+	n := 0
+	for _, v := range ids {
+		if v == id {
+			n++
+		}
+	}
+	return n
+}
+
+func TestFairQueueDoesNotStarveOtherWorkspaces(t *testing.T) {
+	busy, other := uuid.New(), uuid.New()
+	f := NewFairQueue(testRedis(t, busy, other), nil)
+
+	// The busy workspace has a large backlog and has been fed for a while
+	// when the other one queues its executions
+	stageExecutions(t, f, busy, 200)
+	takeExecutions(t, f, 50)
+	stageExecutions(t, f, other, 10)
+
+	taken := takeExecutions(t, f, 20)
+	if taken[0] != other && taken[1] != other {
+		t.Errorf("other workspace waited behind the backlog: %v", taken[:2])
+	}
+	if got := count(taken, other); got < 9 {
+		t.Errorf("other workspace got %d of 20 executions, want its half", got)
+	}
+}
+
+func TestFairQueueWeights(t *testing.T) {
+	heavy, light := uuid.New(), uuid.New()
+	f := NewFairQueue(testRedis(t, heavy, light), nil)
+	f.SetWeightFunc(func(ctx context.Context, workspaceID uuid.UUID) int {
+		if workspaceID == heavy {
+			return 3
+		}
+		return 1
+	})
+
+	stageExecutions(t, f, heavy, 100)
+	stageExecutions(t, f, light, 100)
+
+	taken := takeExecutions(t, f, 40)
+	if got := count(taken, heavy); got < 29 || got > 31 {
+		t.Errorf("workspace weighted 3 got %d of 40 executions, want about 30", got)
+	}
+}
@@ -0,0 +1,62 @@
+package queue
+
+import "github.com/linkflow-ai/linkflow/internal/domain/models"
+
+// Execution priorities, from 1 (lowest) to 10 (highest)
+const (
+	PriorityMin     = 1
+	PriorityMax     = 10
+	PriorityDefault = 5
+	// PriorityInteractive is the default of runs a user started and waits on
+	PriorityInteractive = 8
+)
+
+// DefaultPriority returns the priority of executions that don't set one.
+// Manual, test and replay runs are interactive, so they don't wait behind
+// scheduled and webhook batches.
+func DefaultPriority(triggerType string) int {
+	switch triggerType {
+	case models.TriggerManual, models.TriggerTest, models.TriggerReplay, models.TriggerPartialReplay:
+		return PriorityInteractive
+	default:
+		return PriorityDefault
+	}
+}
+
+// EffectivePriority returns the priority an execution runs at: the one it
+// was given, or the default of its trigger type
+func EffectivePriority(triggerType string, priority int) int {
+	if priority == 0 {
+		priority = DefaultPriority(triggerType)
+	}
+	return NormalizePriority(priority)
+}
+
+// NormalizePriority clamps a priority to 1-10. Zero is the default priority.
+func NormalizePriority(priority int) int {
+	switch {
+	case priority == 0:
+		return PriorityDefault
+	case priority < PriorityMin:
+		return PriorityMin
+	case priority > PriorityMax:
+		return PriorityMax
+	default:
+		return priority
+	}
+}
+
+// QueueForPriority returns the queue executions of a priority run from:
+// critical for 8-10, default for 4-7 and low for 1-3. Workers serve the
+// queues by weight, so higher priorities are picked up first without
+// starving the lower ones.
+func QueueForPriority(priority int) string {
+	switch priority = NormalizePriority(priority); {
+	case priority >= PriorityInteractive:
+		return QueueCritical
+	case priority >= 4:
+		return QueueDefault
+	default:
+		return QueueLow
+	}
+}
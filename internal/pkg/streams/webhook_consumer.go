@@ -25,6 +25,7 @@ const (
 type WebhookConsumer struct {
 	stream       *WebhookStream
 	workflowSvc  *services.WorkflowService
+	executionSvc *services.ExecutionService
 	queueClient  *queue.Client
 	consumerName string
 	wg           sync.WaitGroup
@@ -35,6 +36,7 @@ type WebhookConsumer struct {
 func NewWebhookConsumer(
 	stream *WebhookStream,
 	workflowSvc *services.WorkflowService,
+	executionSvc *services.ExecutionService,
 	queueClient *queue.Client,
 	consumerName string,
 ) *WebhookConsumer {
@@ -45,6 +47,7 @@ func NewWebhookConsumer(
 	return &WebhookConsumer{
 		stream:       stream,
 		workflowSvc:  workflowSvc,
+		executionSvc: executionSvc,
 		queueClient:  queueClient,
 		consumerName: consumerName,
 		stopCh:       make(chan struct{}),
@@ -183,16 +186,33 @@ func (c *WebhookConsumer) processWebhook(ctx context.Context, event WebhookEvent
 		}
 	}
 
+	// Create the execution up front, so its ID can be looked up right away
+	priority := queue.EffectivePriority("webhook", webhook.Priority)
+	execution, err := c.executionSvc.Create(ctx, services.CreateExecutionInput{
+		WorkflowID:  workflow.ID,
+		WorkspaceID: workflow.WorkspaceID,
+		TriggerType: "webhook",
+		InputData:   triggerData,
+		Priority:    priority,
+		Queue:       queue.QueueForPriority(priority),
+	})
+	if err != nil {
+		return err
+	}
+
 	// Queue to Asynq for execution
 	payload := queue.WorkflowExecutionPayload{
 		WorkflowID:  workflow.ID,
 		WorkspaceID: workflow.WorkspaceID,
+		ExecutionID: execution.ID,
 		TriggerType: "webhook",
 		InputData:   triggerData,
+		Priority:    priority,
 	}
 
 	_, err = c.queueClient.EnqueueWorkflowExecution(ctx, payload)
 	if err != nil {
+		_ = c.executionSvc.Fail(ctx, execution.ID, "failed to queue execution", nil)
 		return err
 	}
 
@@ -24,6 +24,8 @@ var (
 	ErrExecutionAlreadyDone   = errors.New("execution is already completed")
 	ErrExecutionNotRetryable  = errors.New("only failed executions can be retried")
 	ErrExecutionNotCancelable = errors.New("only running or queued executions can be cancelled")
+	ErrExecutionNotPausable   = errors.New("only running executions can be paused")
+	ErrExecutionNotPaused     = errors.New("only paused executions can be resumed")
 
 	// Credential errors
 	ErrCredentialInUse    = errors.New("credential is in use by one or more workflows")
@@ -68,7 +70,8 @@ const (
 	ExecutionStatusCompleted = "completed"
 	ExecutionStatusFailed    = "failed"
 	ExecutionStatusCancelled = "cancelled"
-	ExecutionStatusTimeout   = "timeout"
+	ExecutionStatusTimedOut  = "timed_out"
+	ExecutionStatusPaused    = "paused"
 )
 
 // RuleError wraps a rule violation with context
@@ -185,19 +188,35 @@ func NewExecutionRules() *ExecutionRules {
 // CanCancel checks if an execution can be cancelled
 func (r *ExecutionRules) CanCancel(status string) error {
 	switch status {
-	case ExecutionStatusQueued, ExecutionStatusRunning:
+	case ExecutionStatusQueued, ExecutionStatusRunning, ExecutionStatusPaused:
 		return nil
-	case ExecutionStatusCompleted, ExecutionStatusFailed, ExecutionStatusCancelled, ExecutionStatusTimeout:
+	case ExecutionStatusCompleted, ExecutionStatusFailed, ExecutionStatusCancelled, ExecutionStatusTimedOut:
 		return NewRuleError("execution.cancel", ErrExecutionNotCancelable)
 	default:
 		return NewRuleError("execution.cancel", ErrExecutionNotCancelable)
 	}
 }
 
+// CanPause checks if an execution can be paused
+func (r *ExecutionRules) CanPause(status string) error {
+	if status != ExecutionStatusRunning {
+		return NewRuleError("execution.pause", ErrExecutionNotPausable)
+	}
+	return nil
+}
+
+// CanResume checks if an execution can be resumed
+func (r *ExecutionRules) CanResume(status string) error {
+	if status != ExecutionStatusPaused {
+		return NewRuleError("execution.resume", ErrExecutionNotPaused)
+	}
+	return nil
+}
+
 // CanRetry checks if an execution can be retried
 func (r *ExecutionRules) CanRetry(status string) error {
 	switch status {
-	case ExecutionStatusFailed, ExecutionStatusTimeout, ExecutionStatusCancelled:
+	case ExecutionStatusFailed, ExecutionStatusTimedOut, ExecutionStatusCancelled:
 		return nil
 	case ExecutionStatusCompleted:
 		return NewRuleError("execution.retry", ErrExecutionAlreadyDone)
@@ -211,7 +230,7 @@ func (r *ExecutionRules) CanRetry(status string) error {
 // IsTerminal checks if an execution is in a terminal state
 func (r *ExecutionRules) IsTerminal(status string) bool {
 	switch status {
-	case ExecutionStatusCompleted, ExecutionStatusFailed, ExecutionStatusCancelled, ExecutionStatusTimeout:
+	case ExecutionStatusCompleted, ExecutionStatusFailed, ExecutionStatusCancelled, ExecutionStatusTimedOut:
 		return true
 	default:
 		return false
@@ -381,6 +400,16 @@ func CanCancelExecution(status string) error {
 	return executionRules.CanCancel(status)
 }
 
+// CanPauseExecution checks if an execution can be paused
+func CanPauseExecution(status string) error {
+	return executionRules.CanPause(status)
+}
+
+// CanResumeExecution checks if an execution can be resumed
+func CanResumeExecution(status string) error {
+	return executionRules.CanResume(status)
+}
+
 // CanRetryExecution checks if an execution can be retried
 func CanRetryExecution(status string) error {
 	return executionRules.CanRetry(status)
@@ -4,8 +4,9 @@ import "time"
 
 type Config struct {
 	// Polling
-	PollInterval time.Duration
-	BatchSize    int
+	PollInterval     time.Duration
+	BatchSize        int
+	WaitPollInterval time.Duration // how often due waiting executions are resumed
 
 	// Rate Limiting
 	GlobalRateLimit   int // per minute
@@ -28,6 +29,7 @@ func DefaultConfig() *Config {
 	return &Config{
 		PollInterval:      time.Second,
 		BatchSize:         100,
+		WaitPollInterval:  5 * time.Second,
 		GlobalRateLimit:   1000,
 		WorkspaceLimit:    100,
 		LeaderKey:         "scheduler:leader",
@@ -46,6 +48,9 @@ func (c *Config) Validate() error {
 	if c.BatchSize <= 0 {
 		c.BatchSize = 100
 	}
+	if c.WaitPollInterval <= 0 {
+		c.WaitPollInterval = 5 * time.Second
+	}
 	if c.GlobalRateLimit <= 0 {
 		c.GlobalRateLimit = 1000
 	}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/linkflow-ai/linkflow/internal/domain/models"
+	"github.com/linkflow-ai/linkflow/internal/domain/services"
 	"github.com/linkflow-ai/linkflow/internal/pkg/queue"
 	"github.com/linkflow-ai/linkflow/internal/scheduler/store"
 	"github.com/rs/zerolog/log"
@@ -14,6 +15,7 @@ import (
 
 type Dispatcher struct {
 	queue         *queue.Client
+	executionSvc  *services.ExecutionService
 	globalLimiter RateLimiter
 	wsLimiter     RateLimiter
 
@@ -23,9 +25,10 @@ type Dispatcher struct {
 	failed     atomic.Int64
 }
 
-func NewDispatcher(queueClient *queue.Client, globalLimiter, wsLimiter RateLimiter) *Dispatcher {
+func NewDispatcher(queueClient *queue.Client, executionSvc *services.ExecutionService, globalLimiter, wsLimiter RateLimiter) *Dispatcher {
 	return &Dispatcher{
 		queue:         queueClient,
+		executionSvc:  executionSvc,
 		globalLimiter: globalLimiter,
 		wsLimiter:     wsLimiter,
 	}
@@ -56,21 +59,48 @@ func (d *Dispatcher) Dispatch(ctx context.Context, schedule *store.Schedule) *Di
 		return result
 	}
 
+	triggerData := models.JSON{
+		"schedule_id":   schedule.ID.String(),
+		"schedule_name": schedule.Name,
+		"scheduled_at":  time.Now().Format(time.RFC3339),
+	}
+
+	// Create the execution up front, so its ID can be looked up right away
+	priority := queue.EffectivePriority(models.TriggerSchedule, schedule.Priority)
+	execution, err := d.executionSvc.Create(ctx, services.CreateExecutionInput{
+		WorkflowID:  schedule.WorkflowID,
+		WorkspaceID: schedule.WorkspaceID,
+		TriggerType: models.TriggerSchedule,
+		TriggerData: triggerData,
+		InputData:   schedule.InputData,
+		Priority:    priority,
+		Queue:       queue.QueueForPriority(priority),
+	})
+	if err != nil {
+		result.Error = err
+		d.failed.Add(1)
+		log.Error().
+			Err(err).
+			Str("schedule_id", schedule.ID.String()).
+			Str("workflow_id", schedule.WorkflowID.String()).
+			Msg("Failed to create scheduled execution")
+		return result
+	}
+
 	// Enqueue to worker
 	payload := queue.WorkflowExecutionPayload{
 		WorkflowID:  schedule.WorkflowID,
 		WorkspaceID: schedule.WorkspaceID,
+		ExecutionID: execution.ID,
 		TriggerType: models.TriggerSchedule,
 		InputData:   schedule.InputData,
-		TriggerData: models.JSON{
-			"schedule_id":   schedule.ID.String(),
-			"schedule_name": schedule.Name,
-			"scheduled_at":  time.Now().Format(time.RFC3339),
-		},
+		Priority:    priority,
+		TriggerData: triggerData,
 	}
 
-	_, err := d.queue.EnqueueWorkflowExecution(ctx, payload)
+	_, err = d.queue.EnqueueWorkflowExecution(ctx, payload)
 	if err != nil {
+		_ = d.executionSvc.Fail(ctx, execution.ID, "failed to queue execution", nil)
 		result.Error = err
 		d.failed.Add(1)
 		log.Error().
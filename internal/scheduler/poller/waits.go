@@ -0,0 +1,107 @@
+package poller
+
+import (
+	"context"
+	"time"
+
+	"github.com/linkflow-ai/linkflow/internal/domain/models"
+	"github.com/linkflow-ai/linkflow/internal/domain/services"
+	"github.com/linkflow-ai/linkflow/internal/pkg/queue"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// WaitPoller resumes suspended executions whose wait is over: timer waits at
+// their resume time and webhook waits that timed out without being called
+type WaitPoller struct {
+	db           *gorm.DB
+	queue        *queue.Client
+	batchSize    int
+	pollInterval time.Duration
+}
+
+func NewWaitPoller(db *gorm.DB, queueClient *queue.Client, batchSize int, pollInterval time.Duration) *WaitPoller {
+	return &WaitPoller{
+		db:           db,
+		queue:        queueClient,
+		batchSize:    batchSize,
+		pollInterval: pollInterval,
+	}
+}
+
+func (w *WaitPoller) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.poll(ctx)
+		}
+	}
+}
+
+func (w *WaitPoller) poll(ctx context.Context) {
+	var due []models.WaitingExecution
+	err := w.db.WithContext(ctx).
+		Where("status = ? AND timeout_at <= ?", services.WaitStatusWaiting, time.Now()).
+		Order("timeout_at").
+		Limit(w.batchSize).
+		Find(&due).Error
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to fetch due waiting executions")
+		return
+	}
+
+	for i := range due {
+		w.resume(ctx, &due[i])
+	}
+}
+
+// resume claims a waiting execution and queues it to continue
+func (w *WaitPoller) resume(ctx context.Context, waiting *models.WaitingExecution) {
+	var data models.JSON
+	if waiting.ResumeType == services.WaitResumeWebhook {
+		data = models.JSON{"timedOut": true}
+	}
+
+	// Claim it, unless a resume call got there first
+	result := w.db.WithContext(ctx).Model(&models.WaitingExecution{}).
+		Where("id = ? AND status = ?", waiting.ID, services.WaitStatusWaiting).
+		Updates(map[string]interface{}{
+			"status":      services.WaitStatusResumed,
+			"resumed_at":  time.Now(),
+			"resume_data": data,
+		})
+	if result.Error != nil {
+		log.Error().Err(result.Error).Str("waiting_id", waiting.ID.String()).Msg("Failed to claim waiting execution")
+		return
+	}
+	if result.RowsAffected == 0 {
+		return
+	}
+
+	_, err := w.queue.EnqueueWorkflowExecution(ctx, queue.WorkflowExecutionPayload{
+		WorkflowID:  waiting.WorkflowID,
+		WorkspaceID: waiting.WorkspaceID,
+		ExecutionID: waiting.ExecutionID,
+		WaitingID:   waiting.ID,
+		Priority:    waiting.Priority,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("execution_id", waiting.ExecutionID.String()).Msg("Failed to queue resumed execution")
+		// Release the claim so the next poll retries
+		w.db.WithContext(ctx).Model(&models.WaitingExecution{}).
+			Where("id = ?", waiting.ID).
+			Update("status", services.WaitStatusWaiting)
+		return
+	}
+
+	log.Info().
+		Str("execution_id", waiting.ExecutionID.String()).
+		Str("node_id", waiting.NodeID).
+		Str("resume_type", waiting.ResumeType).
+		Msg("Resumed waiting execution")
+}
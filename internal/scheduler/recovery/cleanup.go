@@ -41,7 +41,7 @@ func (c *Cleanup) cleanup(ctx context.Context) {
 
 	// Cleanup old executions
 	result := c.db.WithContext(ctx).
-		Exec("DELETE FROM executions WHERE created_at < ? AND status IN ('completed', 'failed', 'cancelled')", cutoff)
+		Exec("DELETE FROM executions WHERE created_at < ? AND status IN ('completed', 'failed', 'cancelled', 'timed_out')", cutoff)
 
 	if result.Error != nil {
 		log.Error().Err(result.Error).Msg("Failed to cleanup old executions")
@@ -5,6 +5,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/linkflow-ai/linkflow/internal/domain/repositories"
+	"github.com/linkflow-ai/linkflow/internal/domain/services"
 	"github.com/linkflow-ai/linkflow/internal/pkg/queue"
 	pkgredis "github.com/linkflow-ai/linkflow/internal/pkg/redis"
 	"github.com/linkflow-ai/linkflow/internal/scheduler/cron"
@@ -24,6 +26,7 @@ type Scheduler struct {
 	// Components
 	election     *leader.Election
 	poller       *poller.Poller
+	waitPoller   *poller.WaitPoller
 	dispatcher   *dispatcher.Dispatcher
 	staleRecov   *recovery.StaleRecovery
 	cleanup      *recovery.Cleanup
@@ -68,12 +71,21 @@ func New(cfg *Config, deps *Dependencies) *Scheduler {
 		deps.Redis, "scheduler:ratelimit:workspace", cfg.WorkspaceLimit, time.Minute,
 	)
 
-	// Create dispatcher
-	disp := dispatcher.NewDispatcher(deps.Queue, globalLimiter, wsLimiter)
+	// Create dispatcher; it creates the execution records of the schedules
+	workflowRepo := repositories.NewWorkflowRepository(deps.DB)
+	executionSvc := services.NewExecutionService(
+		repositories.NewExecutionRepository(deps.DB),
+		repositories.NewNodeExecutionRepository(deps.DB),
+		workflowRepo,
+	)
+	disp := dispatcher.NewDispatcher(deps.Queue, executionSvc, globalLimiter, wsLimiter)
 
 	// Create poller
 	poll := poller.NewPoller(cachedStore, disp, calculator, cfg.BatchSize, cfg.PollInterval)
 
+	// Create wait poller for suspended executions
+	waitPoll := poller.NewWaitPoller(deps.DB, deps.Queue, cfg.BatchSize, cfg.WaitPollInterval)
+
 	// Create backpressure monitor
 	bp := dispatcher.NewBackpressureMonitor(deps.Redis, "asynq:queue:default", 10000)
 	poll.SetBackpressure(bp)
@@ -89,6 +101,7 @@ func New(cfg *Config, deps *Dependencies) *Scheduler {
 		config:       cfg,
 		election:     election,
 		poller:       poll,
+		waitPoller:   waitPoll,
 		dispatcher:   disp,
 		staleRecov:   staleRecov,
 		cleanup:      cleanup,
@@ -180,11 +193,15 @@ func (s *Scheduler) leaderLoop() {
 		recoveryCtx, recoveryCancel = context.WithCancel(s.ctx)
 		cleanupCtx, cleanupCancel = context.WithCancel(s.ctx)
 
-		s.wg.Add(3)
+		s.wg.Add(4)
 		go func() {
 			defer s.wg.Done()
 			s.poller.Run(pollerCtx)
 		}()
+		go func() {
+			defer s.wg.Done()
+			s.waitPoller.Run(pollerCtx)
+		}()
 		go func() {
 			defer s.wg.Done()
 			s.staleRecov.Run(recoveryCtx)
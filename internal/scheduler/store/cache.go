@@ -29,7 +29,7 @@ func (s *CachedStore) GetDue(ctx context.Context, limit int) ([]*Schedule, error
 	return s.store.GetDue(ctx, limit)
 }
 
-func (s *CachedStore) GetDueByPriority(ctx context.Context, priority string, limit int) ([]*Schedule, error) {
+func (s *CachedStore) GetDueByPriority(ctx context.Context, priority int, limit int) ([]*Schedule, error) {
 	return s.store.GetDueByPriority(ctx, priority, limit)
 }
 
@@ -14,7 +14,7 @@ type Schedule struct {
 	Name           string
 	CronExpression string
 	Timezone       string
-	Priority       string
+	Priority       int
 	InputData      map[string]interface{}
 	NextRunAt      *time.Time
 	LastRunAt      *time.Time
@@ -27,7 +27,7 @@ type ScheduleStore interface {
 	GetDue(ctx context.Context, limit int) ([]*Schedule, error)
 
 	// GetDueByPriority fetches due schedules filtered by priority
-	GetDueByPriority(ctx context.Context, priority string, limit int) ([]*Schedule, error)
+	GetDueByPriority(ctx context.Context, priority int, limit int) ([]*Schedule, error)
 
 	// GetDueByWorkspace fetches due schedules for a specific workspace
 	GetDueByWorkspace(ctx context.Context, workspaceID uuid.UUID, limit int) ([]*Schedule, error)
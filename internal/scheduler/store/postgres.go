@@ -33,7 +33,7 @@ func (s *PostgresStore) GetDue(ctx context.Context, limit int) ([]*Schedule, err
 	return s.toSchedules(schedules), nil
 }
 
-func (s *PostgresStore) GetDueByPriority(ctx context.Context, priority string, limit int) ([]*Schedule, error) {
+func (s *PostgresStore) GetDueByPriority(ctx context.Context, priority int, limit int) ([]*Schedule, error) {
 	var schedules []models.Schedule
 
 	err := s.db.WithContext(ctx).
@@ -124,6 +124,7 @@ func (s *PostgresStore) toSchedule(m *models.Schedule) *Schedule {
 		Name:           m.Name,
 		CronExpression: m.CronExpression,
 		Timezone:       m.Timezone,
+		Priority:       m.Priority,
 		RunCount:       m.RunCount,
 		IsActive:       m.IsActive,
 		NextRunAt:      m.NextRunAt,
@@ -0,0 +1,204 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Codes of a NodeError
+const (
+	ErrCodeInvalidConfig = "INVALID_CONFIG"
+	ErrCodeCredential    = "CREDENTIAL_ERROR"
+	ErrCodeBadRequest    = "BAD_REQUEST"
+	ErrCodeNotFound      = "NOT_FOUND"
+	ErrCodeRateLimited   = "RATE_LIMITED"
+	ErrCodeUpstream      = "UPSTREAM_ERROR"
+	ErrCodeTimeout       = "TIMEOUT"
+	ErrCodeNetwork       = "NETWORK_ERROR"
+	ErrCodeNodeFailed    = "NODE_FAILED"
+)
+
+// maxResponseSnippet caps the upstream response kept on a NodeError
+const maxResponseSnippet = 1024
+
+// NodeError is a structured node failure. It tells the executor, the retry
+// policy and the user what went wrong beyond the message: a bad credential,
+// a transient network error or a missing resource.
+type NodeError struct {
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+	HTTPStatus int    `json:"httpStatus,omitempty"`
+	Retryable  bool   `json:"retryable"`
+	// Response is a snippet of the upstream response body
+	Response string `json:"response,omitempty"`
+	// Hint suggests how the user can fix the error
+	Hint string `json:"hint,omitempty"`
+	// Wait is the delay the upstream asked for (Retry-After)
+	Wait time.Duration `json:"-"`
+	Err  error         `json:"-"`
+}
+
+func (e *NodeError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+	}
+	return e.Message
+}
+
+func (e *NodeError) Unwrap() error { return e.Err }
+
+func (e *NodeError) StatusCode() int { return e.HTTPStatus }
+
+func (e *NodeError) RetryAfter() time.Duration { return e.Wait }
+
+func (e *NodeError) ErrorCode() string { return e.Code }
+
+// ToMap converts the error for storage and events
+func (e *NodeError) ToMap() map[string]interface{} {
+	m := map[string]interface{}{
+		"code":      e.Code,
+		"message":   e.Error(),
+		"retryable": e.Retryable,
+	}
+	if e.HTTPStatus != 0 {
+		m["httpStatus"] = e.HTTPStatus
+	}
+	if e.Response != "" {
+		m["response"] = e.Response
+	}
+	if e.Hint != "" {
+		m["hint"] = e.Hint
+	}
+	return m
+}
+
+// NewAPIError builds the error of a failed upstream HTTP response. service
+// names the API in the default message; message overrides it when the node
+// extracted a better one from the response.
+func NewAPIError(service string, resp *http.Response, body []byte, message string) *NodeError {
+	if message == "" {
+		message = fmt.Sprintf("%s API error: %s", service, resp.Status)
+	}
+
+	e := &NodeError{
+		Message:    message,
+		HTTPStatus: resp.StatusCode,
+		Response:   snippet(body),
+		Wait:       ParseRetryAfter(resp.Header.Get("Retry-After")),
+	}
+
+	switch status := resp.StatusCode; {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		e.Code = ErrCodeCredential
+		e.Hint = fmt.Sprintf("Check that the %s credential is valid and has access to this resource", service)
+	case status == http.StatusNotFound:
+		e.Code = ErrCodeNotFound
+		e.Hint = "Check the IDs and URL in the node configuration"
+	case status == http.StatusTooManyRequests:
+		e.Code = ErrCodeRateLimited
+		e.Retryable = true
+		e.Hint = fmt.Sprintf("%s is rate limiting requests; retry later or lower the request rate", service)
+	case status == http.StatusRequestTimeout:
+		e.Code = ErrCodeTimeout
+		e.Retryable = true
+	case status >= 500:
+		e.Code = ErrCodeUpstream
+		e.Retryable = true
+		e.Hint = fmt.Sprintf("%s returned a server error; it is usually temporary", service)
+	default:
+		e.Code = ErrCodeBadRequest
+		e.Hint = "Check the node configuration against the API documentation"
+	}
+	return e
+}
+
+// NewRequestError builds the error of a request that got no response
+func NewRequestError(message string, err error) *NodeError {
+	e := &NodeError{Code: ErrCodeNetwork, Message: message, Retryable: true, Err: err}
+	if IsTimeout(err) {
+		e.Code = ErrCodeTimeout
+		e.Hint = "The request timed out; increase the node timeout or retry"
+	} else {
+		e.Hint = "Check that the host is reachable from the worker"
+	}
+	return e
+}
+
+// NewCredentialError builds the error of a missing or unusable credential
+func NewCredentialError(message string, err error) *NodeError {
+	return &NodeError{
+		Code:    ErrCodeCredential,
+		Message: message,
+		Err:     err,
+		Hint:    "Select a valid credential for this node",
+	}
+}
+
+// NewConfigError builds the error of an invalid node configuration
+func NewConfigError(message string, err error) *NodeError {
+	return &NodeError{Code: ErrCodeInvalidConfig, Message: message, Err: err}
+}
+
+// AsNodeError returns the NodeError in err's chain
+func AsNodeError(err error) (*NodeError, bool) {
+	var e *NodeError
+	if errors.As(err, &e) {
+		return e, true
+	}
+	return nil, false
+}
+
+// ToNodeError classifies any node failure; plain errors become
+// ErrCodeNodeFailed unless they are timeouts or network errors
+func ToNodeError(err error) *NodeError {
+	if e, ok := AsNodeError(err); ok {
+		if e.Error() == err.Error() {
+			return e
+		}
+		// Keep the context added by wrapping the error
+		wrapped := *e
+		wrapped.Message = err.Error()
+		wrapped.Err = nil
+		return &wrapped
+	}
+
+	var netErr net.Error
+	switch {
+	case IsTimeout(err):
+		return &NodeError{Code: ErrCodeTimeout, Message: err.Error(), Retryable: true}
+	case errors.As(err, &netErr):
+		return &NodeError{Code: ErrCodeNetwork, Message: err.Error(), Retryable: true}
+	}
+	return &NodeError{Code: ErrCodeNodeFailed, Message: err.Error()}
+}
+
+// IsRetryable reports whether a failure may succeed when retried. Only
+// NodeErrors can rule it out.
+func IsRetryable(err error) bool {
+	if e, ok := AsNodeError(err); ok {
+		return e.Retryable
+	}
+	return true
+}
+
+// IsTimeout reports whether an error is a deadline or network timeout
+func IsTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+func snippet(body []byte) string {
+	s := strings.TrimSpace(string(body))
+	if len(s) > maxResponseSnippet {
+		return s[:maxResponseSnippet] + "..."
+	}
+	return s
+}
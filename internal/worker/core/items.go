@@ -0,0 +1,64 @@
+package core
+
+// Execution modes control how a node consumes its input items
+const (
+	// ModeOnceForAllItems runs the node once with every input item available
+	// in ExecutionContext.Items (the default)
+	ModeOnceForAllItems = "runOnceForAllItems"
+	// ModeOnceForEachItem runs the node once per input item, with the item
+	// exposed as $json, $item and $itemIndex
+	ModeOnceForEachItem = "runOnceForEachItem"
+)
+
+// Item is a single record flowing between nodes
+type Item struct {
+	JSON map[string]interface{} `json:"json"`
+}
+
+// ItemsFromOutput converts a node output into items. An output carrying an
+// "items" array becomes one item per entry; any other output is a single item.
+// Entries that are not objects are wrapped as {"value": entry}.
+func ItemsFromOutput(output interface{}) []Item {
+	switch v := output.(type) {
+	case nil:
+		return nil
+	case map[string]interface{}:
+		switch list := v["items"].(type) {
+		case []interface{}:
+			return ItemsFromList(list)
+		case []map[string]interface{}:
+			items := make([]Item, len(list))
+			for i, entry := range list {
+				items[i] = Item{JSON: entry}
+			}
+			return items
+		}
+		return []Item{{JSON: v}}
+	case []interface{}:
+		return ItemsFromList(v)
+	default:
+		return []Item{{JSON: map[string]interface{}{"value": v}}}
+	}
+}
+
+// ItemsFromList converts a list of values into items
+func ItemsFromList(list []interface{}) []Item {
+	items := make([]Item, len(list))
+	for i, entry := range list {
+		if m, ok := entry.(map[string]interface{}); ok {
+			items[i] = Item{JSON: m}
+		} else {
+			items[i] = Item{JSON: map[string]interface{}{"value": entry}}
+		}
+	}
+	return items
+}
+
+// ItemsToList returns the JSON of each item as a list
+func ItemsToList(items []Item) []interface{} {
+	list := make([]interface{}, len(items))
+	for i, item := range items {
+		list[i] = item.JSON
+	}
+	return list
+}
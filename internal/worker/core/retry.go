@@ -0,0 +1,69 @@
+package core
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Errors can describe themselves to the retry policy by implementing any of
+// these interfaces. Wrapped errors are inspected with errors.As.
+type (
+	// StatusCoder is implemented by errors caused by an HTTP response
+	StatusCoder interface {
+		StatusCode() int
+	}
+	// RetryAfterer is implemented by errors whose source asked to be retried
+	// after a delay (Retry-After)
+	RetryAfterer interface {
+		RetryAfter() time.Duration
+	}
+	// ErrorCoder is implemented by errors with an application error code
+	ErrorCoder interface {
+		ErrorCode() string
+	}
+)
+
+// ParseRetryAfter reads a Retry-After header given in seconds or as an HTTP date
+func ParseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if d := time.Until(at); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// ErrorStatusCode returns the HTTP status of an error, or 0
+func ErrorStatusCode(err error) int {
+	var s StatusCoder
+	if errors.As(err, &s) {
+		return s.StatusCode()
+	}
+	return 0
+}
+
+// ErrorRetryAfter returns the delay an error asked to be retried after, or 0
+func ErrorRetryAfter(err error) time.Duration {
+	var r RetryAfterer
+	if errors.As(err, &r) {
+		return r.RetryAfter()
+	}
+	return 0
+}
+
+// ErrorCode returns the application error code of an error, or ""
+func ErrorCode(err error) string {
+	var c ErrorCoder
+	if errors.As(err, &c) {
+		return c.ErrorCode()
+	}
+	return ""
+}
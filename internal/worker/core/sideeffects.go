@@ -0,0 +1,41 @@
+package core
+
+import "context"
+
+// SideEffecter is implemented by nodes that can change state outside the
+// workflow (send messages, write records, charge cards). In dry-run mode the
+// processor does not execute a node whose HasSideEffects reports true for its
+// resolved config; it returns a simulated response instead. Nodes that don't
+// implement it are treated as pure and run for real.
+type SideEffecter interface {
+	HasSideEffects(config map[string]interface{}) bool
+}
+
+// DryRunner is implemented by side-effect nodes that can describe the request
+// they would send more precisely than SimulateSideEffect
+type DryRunner interface {
+	DryRun(ctx context.Context, execCtx *ExecutionContext) (map[string]interface{}, error)
+}
+
+// HasSideEffects reports whether a node would change external state when run
+// with the given config
+func HasSideEffects(node Node, config map[string]interface{}) bool {
+	s, ok := node.(SideEffecter)
+	return ok && s.HasSideEffects(config)
+}
+
+// SimulateSideEffect builds the dry-run response of a side-effect node that
+// was not executed
+func SimulateSideEffect(ctx context.Context, node Node, execCtx *ExecutionContext) (map[string]interface{}, error) {
+	if d, ok := node.(DryRunner); ok {
+		return d.DryRun(ctx, execCtx)
+	}
+
+	return map[string]interface{}{
+		"dryRun":    true,
+		"nodeType":  node.Type(),
+		"operation": execCtx.Config["operation"],
+		"request":   execCtx.Config,
+		"message":   "Node not executed: it has side effects and this is a dry run",
+	}, nil
+}
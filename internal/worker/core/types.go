@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/linkflow-ai/linkflow/internal/domain/models"
+	"github.com/linkflow-ai/linkflow/internal/pkg/email"
 	"github.com/linkflow-ai/linkflow/internal/pkg/queue"
 	"github.com/redis/go-redis/v9"
 )
@@ -17,6 +18,8 @@ type ExecutionContext struct {
 	WorkspaceID   uuid.UUID
 	NodeID        string
 	Input         map[string]interface{}
+	Items         []Item // Input items from upstream nodes
+	ItemIndex     int    // Index of the current item when running once per item
 	Config        map[string]interface{}
 	Variables     map[string]interface{}
 	Credentials   map[string]interface{}
@@ -29,6 +32,25 @@ type Node interface {
 	Execute(ctx context.Context, execCtx *ExecutionContext) (map[string]interface{}, error)
 }
 
+// Router is implemented by nodes that send their output down only some of
+// their output handles (IF, Switch). Routes returns the handles selected for
+// the given output; connections from any other handle are not followed.
+type Router interface {
+	Routes(output map[string]interface{}) []string
+}
+
+// Looper is implemented by nodes that drive a loop body. The processor runs
+// the sub-graph wired to the node's "loop" handle once per iteration and then
+// sends the aggregated iteration results down its "done" handle.
+//
+// NextIteration is called before each iteration with the node's config
+// (re-resolved so expressions can reference $loop), the node's own output and
+// the zero-based iteration index. It returns the iteration context exposed to
+// the body, or false when the loop is finished.
+type Looper interface {
+	NextIteration(config, output map[string]interface{}, index int) (map[string]interface{}, bool)
+}
+
 // NodeMeta contains metadata about a node for UI and discovery
 type NodeMeta struct {
 	Type        string   `json:"type"`
@@ -42,8 +64,10 @@ type NodeMeta struct {
 
 // Dependencies holds external dependencies for nodes that need them
 type Dependencies struct {
-	QueueClient *queue.Client
-	RedisClient *redis.Client
+	QueueClient  *queue.Client
+	RedisClient  *redis.Client
+	EmailService *email.Service
+	BaseURL      string // public URL of the API, for links sent by nodes
 }
 
 // NodeWithDeps is for nodes that require dependencies
@@ -0,0 +1,53 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Resume types of a suspended execution
+const (
+	// ResumeTimer resumes the execution at ResumeAt
+	ResumeTimer = "timer"
+	// ResumeWebhook resumes the execution through its resume URL, or at
+	// ResumeAt with {"timedOut": true} if nobody called it
+	ResumeWebhook = "webhook"
+)
+
+// Suspension is returned as the error of a node that parks the execution
+// until a point in time or an external resume call. The processor saves the
+// execution state and releases the worker instead of failing the node; when
+// the execution resumes, Output becomes the node's output with the resume
+// data added as "resumeData".
+type Suspension struct {
+	ResumeType string    `json:"resumeType"`
+	ResumeAt   time.Time `json:"resumeAt"`
+	// ResumeToken is set by nodes that hand out their resume URL before
+	// suspending; otherwise a token is generated when the wait is stored
+	ResumeToken string                 `json:"resumeToken,omitempty"`
+	Output      map[string]interface{} `json:"output,omitempty"`
+	// Notify hands the resume URL out, e.g. to approvers. It's called once
+	// the wait is stored, so the URL works by the time anybody opens it.
+	Notify func(ctx context.Context) error `json:"-"`
+}
+
+func (s *Suspension) Error() string {
+	return fmt.Sprintf("execution suspended (%s) until %s", s.ResumeType, s.ResumeAt.Format(time.RFC3339))
+}
+
+// Resumer is implemented by waiting nodes that build their own output when the
+// execution resumes, instead of getting the resume data added as "resumeData"
+type Resumer interface {
+	Resume(output, data map[string]interface{}) map[string]interface{}
+}
+
+// AsSuspension reports whether err asks for the execution to be suspended
+func AsSuspension(err error) (*Suspension, bool) {
+	var s *Suspension
+	if errors.As(err, &s) {
+		return s, true
+	}
+	return nil, false
+}
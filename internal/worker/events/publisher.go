@@ -24,9 +24,12 @@ const (
 	EventExecutionCompleted EventType = "execution.completed"
 	EventExecutionFailed    EventType = "execution.failed"
 	EventExecutionCancelled EventType = "execution.cancelled"
+	EventExecutionPaused     EventType = "execution.paused"
 	EventNodeStarted        EventType = "node.started"
 	EventNodeCompleted      EventType = "node.completed"
 	EventNodeFailed         EventType = "node.failed"
+	EventNodeSkipped        EventType = "node.skipped"
+	EventNodeRetrying       EventType = "node.retrying"
 	EventWorkflowActivated  EventType = "workflow.activated"
 	EventWorkflowDeactivated EventType = "workflow.deactivated"
 )
@@ -81,8 +84,17 @@ func (p *Publisher) ExecutionCompleted(ctx context.Context, workspaceID, workflo
 }
 
 func (p *Publisher) ExecutionFailed(ctx context.Context, workspaceID, workflowID, executionID uuid.UUID, errorMsg string, errorNodeID *string) error {
+	return p.executionFailed(ctx, workspaceID, workflowID, executionID, "failed", errorMsg, errorNodeID)
+}
+
+// ExecutionTimedOut publishes a failure event with the timed out status
+func (p *Publisher) ExecutionTimedOut(ctx context.Context, workspaceID, workflowID, executionID uuid.UUID, errorMsg string, errorNodeID *string) error {
+	return p.executionFailed(ctx, workspaceID, workflowID, executionID, "timed_out", errorMsg, errorNodeID)
+}
+
+func (p *Publisher) executionFailed(ctx context.Context, workspaceID, workflowID, executionID uuid.UUID, status, errorMsg string, errorNodeID *string) error {
 	data := map[string]interface{}{
-		"status": "failed",
+		"status": status,
 		"error":  errorMsg,
 	}
 	if errorNodeID != nil {
@@ -98,6 +110,35 @@ func (p *Publisher) ExecutionFailed(ctx context.Context, workspaceID, workflowID
 	})
 }
 
+// ExecutionCancelled publishes the cancellation of an execution
+func (p *Publisher) ExecutionCancelled(ctx context.Context, workspaceID, workflowID, executionID uuid.UUID, reason string) error {
+	return p.Publish(ctx, &Event{
+		Type:        EventExecutionCancelled,
+		WorkspaceID: workspaceID,
+		WorkflowID:  workflowID,
+		ExecutionID: executionID,
+		Data: map[string]interface{}{
+			"status": "cancelled",
+			"reason": reason,
+		},
+	})
+}
+
+// ExecutionPaused publishes the pause of an execution after nodesCompleted
+// nodes
+func (p *Publisher) ExecutionPaused(ctx context.Context, workspaceID, workflowID, executionID uuid.UUID, nodesCompleted int) error {
+	return p.Publish(ctx, &Event{
+		Type:        EventExecutionPaused,
+		WorkspaceID: workspaceID,
+		WorkflowID:  workflowID,
+		ExecutionID: executionID,
+		Data: map[string]interface{}{
+			"status":          "paused",
+			"nodes_completed": nodesCompleted,
+		},
+	})
+}
+
 func (p *Publisher) NodeStarted(ctx context.Context, workspaceID, workflowID, executionID uuid.UUID, nodeID, nodeType, nodeName string) error {
 	return p.Publish(ctx, &Event{
 		Type:        EventNodeStarted,
@@ -128,16 +169,71 @@ func (p *Publisher) NodeCompleted(ctx context.Context, workspaceID, workflowID,
 	})
 }
 
-func (p *Publisher) NodeFailed(ctx context.Context, workspaceID, workflowID, executionID uuid.UUID, nodeID, errorMsg string) error {
+// NodePinned publishes a node completion that used pinned data instead of
+// running the node
+func (p *Publisher) NodePinned(ctx context.Context, workspaceID, workflowID, executionID uuid.UUID, nodeID string, outputPreview interface{}) error {
+	return p.Publish(ctx, &Event{
+		Type:        EventNodeCompleted,
+		WorkspaceID: workspaceID,
+		WorkflowID:  workflowID,
+		ExecutionID: executionID,
+		NodeID:      nodeID,
+		Data: map[string]interface{}{
+			"status":         "completed",
+			"pinned":         true,
+			"duration_ms":    0,
+			"output_preview": outputPreview,
+		},
+	})
+}
+
+// NodeFailed publishes a failed node. details describe the error (code,
+// HTTP status, retryable, hint) and may be nil.
+func (p *Publisher) NodeFailed(ctx context.Context, workspaceID, workflowID, executionID uuid.UUID, nodeID, errorMsg string, details map[string]interface{}) error {
+	data := map[string]interface{}{
+		"status": "failed",
+		"error":  errorMsg,
+	}
+	if details != nil {
+		data["error_details"] = details
+	}
 	return p.Publish(ctx, &Event{
 		Type:        EventNodeFailed,
 		WorkspaceID: workspaceID,
 		WorkflowID:  workflowID,
 		ExecutionID: executionID,
 		NodeID:      nodeID,
+		Data:        data,
+	})
+}
+
+// NodeRetrying publishes a failed node attempt that will be retried after
+// delayMs
+func (p *Publisher) NodeRetrying(ctx context.Context, workspaceID, workflowID, executionID uuid.UUID, nodeID string, attempt int, delayMs int64, errorMsg string) error {
+	return p.Publish(ctx, &Event{
+		Type:        EventNodeRetrying,
+		WorkspaceID: workspaceID,
+		WorkflowID:  workflowID,
+		ExecutionID: executionID,
+		NodeID:      nodeID,
+		Data: map[string]interface{}{
+			"status":   "retrying",
+			"attempt":  attempt,
+			"delay_ms": delayMs,
+			"error":    errorMsg,
+		},
+	})
+}
+
+func (p *Publisher) NodeSkipped(ctx context.Context, workspaceID, workflowID, executionID uuid.UUID, nodeID string) error {
+	return p.Publish(ctx, &Event{
+		Type:        EventNodeSkipped,
+		WorkspaceID: workspaceID,
+		WorkflowID:  workflowID,
+		ExecutionID: executionID,
+		NodeID:      nodeID,
 		Data: map[string]interface{}{
-			"status": "failed",
-			"error":  errorMsg,
+			"status": "skipped",
 		},
 	})
 }
@@ -0,0 +1,129 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/linkflow-ai/linkflow/internal/domain/services"
+	"github.com/linkflow-ai/linkflow/internal/worker/processor"
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisCheckpointer stores execution checkpoints in Redis. Checkpoints expire
+// after ttl, so executions that are never recovered don't leak keys.
+type RedisCheckpointer struct {
+	client    *redis.Client
+	ttl       time.Duration
+	encryptor services.Encryptor
+}
+
+// NewRedisCheckpointer creates a Redis checkpoint store
+func NewRedisCheckpointer(client *redis.Client, ttl time.Duration) *RedisCheckpointer {
+	if ttl <= 0 {
+		ttl = 24 * time.Hour
+	}
+	return &RedisCheckpointer{client: client, ttl: ttl}
+}
+
+// SetEncryptor encrypts the checkpoints, which hold the raw node outputs
+func (c *RedisCheckpointer) SetEncryptor(encryptor services.Encryptor) {
+	c.encryptor = encryptor
+}
+
+// Save replaces the execution's checkpoint
+func (c *RedisCheckpointer) Save(ctx context.Context, executionID uuid.UUID, state *processor.ExecutionState) error {
+	if c.encryptor != nil {
+		sealed, err := sealData(c.encryptor, state)
+		if err != nil {
+			return err
+		}
+		return c.client.Set(ctx, checkpointKey(executionID), sealed, c.ttl).Err()
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to serialize checkpoint: %w", err)
+	}
+	return c.client.Set(ctx, checkpointKey(executionID), data, c.ttl).Err()
+}
+
+// Load returns the execution's checkpoint, or nil if it has none
+func (c *RedisCheckpointer) Load(ctx context.Context, executionID uuid.UUID) (*processor.ExecutionState, error) {
+	data, err := c.client.Get(ctx, checkpointKey(executionID)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var state processor.ExecutionState
+	if len(data) > 0 && data[0] != '{' {
+		// Encrypted checkpoint
+		if err := openData(c.encryptor, string(data), &state); err != nil {
+			return nil, err
+		}
+		return &state, nil
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	return &state, nil
+}
+
+// Delete removes the execution's checkpoint
+func (c *RedisCheckpointer) Delete(ctx context.Context, executionID uuid.UUID) error {
+	return c.client.Del(ctx, checkpointKey(executionID)).Err()
+}
+
+func checkpointKey(executionID uuid.UUID) string {
+	return fmt.Sprintf("execution:checkpoint:%s", executionID)
+}
+
+// executionLeaseTTL is how long an execution stays owned by a worker that
+// stopped renewing its lease
+const executionLeaseTTL = 30 * time.Second
+
+// acquireLease marks the execution as owned by this worker while it runs, so
+// a re-delivered task can tell a crashed worker from a slow one. The lease
+// is renewed until release is called.
+func (e *Executor) acquireLease(ctx context.Context, executionID uuid.UUID) (release func(), ok bool) {
+	key := leaseKey(executionID)
+	acquired, err := e.redis.SetNX(ctx, key, 1, executionLeaseTTL).Result()
+	if err != nil {
+		// Without Redis there is nothing to coordinate with
+		return func() {}, true
+	}
+	if !acquired {
+		return nil, false
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(executionLeaseTTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				e.redis.Expire(context.Background(), key, executionLeaseTTL)
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		e.redis.Del(context.Background(), key)
+	}, true
+}
+
+const leaseKeyPrefix = "execution:lease:"
+
+func leaseKey(executionID uuid.UUID) string {
+	return leaseKeyPrefix + executionID.String()
+}
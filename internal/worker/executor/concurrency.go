@@ -0,0 +1,207 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/linkflow-ai/linkflow/internal/domain/models"
+	"github.com/linkflow-ai/linkflow/internal/domain/services"
+	"github.com/linkflow-ai/linkflow/internal/pkg/queue"
+	"github.com/linkflow-ai/linkflow/internal/worker/processor"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// concurrencyRequeueDelay is how long a queued overflow execution waits
+	// before it tries to get a slot again
+	concurrencyRequeueDelay = 10 * time.Second
+	// cancelOldestWait is how long an execution waits for the slot of the
+	// execution it cancelled
+	cancelOldestWait = 30 * time.Second
+	// semaphoreTTL expires the semaphores of workflows that stopped running
+	semaphoreTTL = 24 * time.Hour
+)
+
+// acquireSlotScript takes a slot of a workflow's semaphore, a sorted set of
+// the executions holding one by the time they got it. Holders whose
+// execution lease expired lost their worker and are evicted first.
+//
+// KEYS[1] semaphore, ARGV[1] execution ID, ARGV[2] limit, ARGV[3] now in
+// milliseconds, ARGV[4] lease key prefix, ARGV[5] semaphore TTL in
+// milliseconds
+var acquireSlotScript = redis.NewScript(`
+local holders = redis.call('ZRANGE', KEYS[1], 0, -1)
+for _, holder in ipairs(holders) do
+	if holder ~= ARGV[1] and redis.call('EXISTS', ARGV[4] .. holder) == 0 then
+		redis.call('ZREM', KEYS[1], holder)
+	end
+end
+if redis.call('ZSCORE', KEYS[1], ARGV[1]) then
+	return 1
+end
+if redis.call('ZCARD', KEYS[1]) >= tonumber(ARGV[2]) then
+	return 0
+end
+redis.call('ZADD', KEYS[1], ARGV[3], ARGV[1])
+redis.call('PEXPIRE', KEYS[1], ARGV[5])
+return 1
+`)
+
+func semaphoreKey(workflowID uuid.UUID) string {
+	return fmt.Sprintf("workflow:concurrency:%s", workflowID)
+}
+
+// limitConcurrency enforces the workflow's MaxConcurrency. It returns the
+// function releasing the execution's slot, or run false when the overflow
+// policy queued or dropped the execution. Executions that already started
+// (resumed or recovered ones) are always queued, never dropped.
+func (e *Executor) limitConcurrency(ctx context.Context, execution *models.Execution, payload queue.WorkflowExecutionPayload, settings processor.WorkflowSettings, started bool) (release func(), run bool, err error) {
+	if settings.MaxConcurrency <= 0 {
+		return func() {}, true, nil
+	}
+
+	release = func() {
+		e.redis.ZRem(context.Background(), semaphoreKey(execution.WorkflowID), execution.ID.String())
+	}
+
+	acquired, err := e.acquireSlot(ctx, execution, settings.MaxConcurrency)
+	if err != nil {
+		// Without Redis there is nothing to coordinate with
+		log.Warn().Err(err).
+			Str("workflow_id", execution.WorkflowID.String()).
+			Msg("Failed to acquire workflow concurrency slot")
+		return func() {}, true, nil
+	}
+	if acquired {
+		return release, true, nil
+	}
+
+	policy := settings.OverflowPolicy
+	if started {
+		policy = processor.OverflowQueue
+	}
+
+	switch policy {
+	case processor.OverflowDrop:
+		return nil, false, e.dropExecution(ctx, execution, settings.MaxConcurrency)
+	case processor.OverflowCancelOldest:
+		if e.cancelOldest(ctx, execution, settings.MaxConcurrency) {
+			return release, true, nil
+		}
+	}
+	return nil, false, e.requeue(ctx, execution, payload, settings.MaxConcurrency)
+}
+
+func (e *Executor) acquireSlot(ctx context.Context, execution *models.Execution, limit int) (bool, error) {
+	acquired, err := acquireSlotScript.Run(ctx, e.redis,
+		[]string{semaphoreKey(execution.WorkflowID)},
+		execution.ID.String(), limit, time.Now().UnixMilli(), leaseKeyPrefix, semaphoreTTL.Milliseconds(),
+	).Int()
+	return acquired == 1, err
+}
+
+// dropExecution cancels an execution over the concurrency limit
+func (e *Executor) dropExecution(ctx context.Context, execution *models.Execution, limit int) error {
+	if err := e.executionSvc.Cancel(ctx, execution.ID); err != nil {
+		return fmt.Errorf("failed to drop execution: %w", err)
+	}
+
+	reason := fmt.Sprintf("Dropped: workflow concurrency limit of %d reached", limit)
+	if e.publisher != nil {
+		_ = e.publisher.ExecutionCancelled(ctx, execution.WorkspaceID, execution.WorkflowID, execution.ID, reason)
+	}
+
+	log.Info().
+		Str("execution_id", execution.ID.String()).
+		Str("workflow_id", execution.WorkflowID.String()).
+		Int("max_concurrency", limit).
+		Msg("Dropped execution over workflow concurrency limit")
+	return nil
+}
+
+// cancelOldest cancels the workflow's oldest running execution and waits for
+// its slot. It reports whether the execution got the slot.
+func (e *Executor) cancelOldest(ctx context.Context, execution *models.Execution, limit int) bool {
+	if e.cancellation == nil {
+		return false
+	}
+
+	holders, err := e.redis.ZRange(ctx, semaphoreKey(execution.WorkflowID), 0, -1).Result()
+	if err != nil {
+		return false
+	}
+	var oldest uuid.UUID
+	for _, holder := range holders {
+		if id, err := uuid.Parse(holder); err == nil && id != execution.ID {
+			oldest = id
+			break
+		}
+	}
+	if oldest == uuid.Nil {
+		return false
+	}
+
+	// It's marked cancelled first, so its worker ends it as cancelled
+	// instead of failed
+	reason := fmt.Sprintf("Cancelled for execution %s: workflow concurrency limit of %d reached", execution.ID, limit)
+	if err := e.executionSvc.Cancel(ctx, oldest); err == nil {
+		if e.publisher != nil {
+			_ = e.publisher.ExecutionCancelled(ctx, execution.WorkspaceID, execution.WorkflowID, oldest, reason)
+		}
+		if err := e.cancellation.Cancel(ctx, oldest, reason, "system"); err != nil {
+			log.Warn().Err(err).
+				Str("execution_id", oldest.String()).
+				Msg("Failed to cancel oldest execution")
+			return false
+		}
+	} else if !errors.Is(err, services.ErrExecutionNotRunning) {
+		log.Warn().Err(err).
+			Str("execution_id", oldest.String()).
+			Msg("Failed to cancel oldest execution")
+		return false
+	}
+
+	ticker := time.NewTicker(250 * time.Millisecond)
+	defer ticker.Stop()
+	timeout := time.NewTimer(cancelOldestWait)
+	defer timeout.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-timeout.C:
+			return false
+		case <-ticker.C:
+			if acquired, err := e.acquireSlot(ctx, execution, limit); err == nil && acquired {
+				return true
+			}
+		}
+	}
+}
+
+// requeue runs an execution over the concurrency limit again later. It stays
+// queued in the meantime.
+func (e *Executor) requeue(ctx context.Context, execution *models.Execution, payload queue.WorkflowExecutionPayload, limit int) error {
+	if e.queueClient == nil {
+		// The task is retried with the queue's backoff instead
+		return fmt.Errorf("workflow %s is at its concurrency limit of %d", execution.WorkflowID, limit)
+	}
+
+	payload.ExecutionID = execution.ID
+	if _, err := e.queueClient.EnqueueDelayedWorkflowExecution(ctx, payload, concurrencyRequeueDelay); err != nil {
+		return fmt.Errorf("failed to requeue execution: %w", err)
+	}
+
+	log.Debug().
+		Str("execution_id", execution.ID.String()).
+		Str("workflow_id", execution.WorkflowID.String()).
+		Int("max_concurrency", limit).
+		Dur("delay", concurrencyRequeueDelay).
+		Msg("Requeued execution over workflow concurrency limit")
+	return nil
+}
@@ -0,0 +1,56 @@
+package executor
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/linkflow-ai/linkflow/internal/domain/services"
+	"github.com/linkflow-ai/linkflow/internal/worker/processor"
+)
+
+// SetEnvironmentVariables exposes the workspace environment variables to
+// executions as $env. Executions use the environment (dev, staging or prod)
+// of their workflow's settings, or the given one when the workflow sets none.
+// The app environment names "development" and "production" are accepted as
+// well.
+func (e *Executor) SetEnvironmentVariables(envVarSvc *services.EnvironmentVariableService, environment string) {
+	e.envVarSvc = envVarSvc
+	e.environment = normalizeEnvironment(environment)
+}
+
+// loadEnv returns the decrypted environment variables of a workspace for the
+// workflow's environment
+func (e *Executor) loadEnv(ctx context.Context, workspaceID uuid.UUID, settings processor.WorkflowSettings) (map[string]processor.EnvVar, error) {
+	if e.envVarSvc == nil {
+		return nil, nil
+	}
+
+	var environment *string
+	if env := normalizeEnvironment(settings.Environment); env != "" {
+		environment = &env
+	} else if e.environment != "" {
+		environment = &e.environment
+	}
+	vars, err := e.envVarSvc.GetDecryptedVars(ctx, workspaceID, environment)
+	if err != nil {
+		return nil, err
+	}
+
+	env := make(map[string]processor.EnvVar, len(vars))
+	for name, v := range vars {
+		env[name] = processor.EnvVar{Value: v.Value, Secret: v.IsSecret}
+	}
+	return env, nil
+}
+
+func normalizeEnvironment(environment string) string {
+	switch env := strings.ToLower(environment); env {
+	case "development":
+		return "dev"
+	case "production":
+		return "prod"
+	default:
+		return env
+	}
+}
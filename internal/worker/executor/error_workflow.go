@@ -0,0 +1,144 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/linkflow-ai/linkflow/internal/domain/models"
+	"github.com/linkflow-ai/linkflow/internal/domain/services"
+	"github.com/linkflow-ai/linkflow/internal/pkg/queue"
+	"github.com/linkflow-ai/linkflow/internal/worker/processor"
+	"github.com/rs/zerolog/log"
+)
+
+// SetErrorWorkflowQueue enables error workflows: failed executions of a
+// workflow with an ErrorWorkflowID enqueue that workflow. appURL is used for
+// the link to the failed execution.
+func (e *Executor) SetErrorWorkflowQueue(queueClient *queue.Client, appURL string) {
+	e.queueClient = queueClient
+	e.appURL = strings.TrimRight(appURL, "/")
+}
+
+// triggerErrorWorkflow enqueues the error workflow configured for the failed
+// execution's workflow. Executions started by an error workflow never trigger
+// one themselves, so a failing error workflow cannot loop.
+func (e *Executor) triggerErrorWorkflow(ctx context.Context, execution *models.Execution, payload queue.WorkflowExecutionPayload, errMsg string, result *processor.Result) {
+	if e.queueClient == nil || payload.DryRun || payload.TriggerType == models.TriggerError {
+		return
+	}
+
+	workflow, err := e.workflowSvc.GetByID(ctx, payload.WorkflowID)
+	if err != nil || workflow.ErrorWorkflowID == nil || *workflow.ErrorWorkflowID == workflow.ID {
+		return
+	}
+
+	timedOut := result != nil && result.TimedOut
+	if !errorTriggerMatches(workflow.ErrorTrigger, timedOut) {
+		return
+	}
+
+	if e.billingSvc != nil {
+		allowed, err := e.billingSvc.CheckFeature(ctx, workflow.WorkspaceID, services.FeatureErrorWorkflow)
+		if err != nil || !allowed {
+			log.Debug().
+				Str("workflow_id", workflow.ID.String()).
+				Msg("Error workflow not available on the workspace plan")
+			return
+		}
+	}
+
+	errorWorkflow, err := e.workflowSvc.GetByID(ctx, *workflow.ErrorWorkflowID)
+	if err != nil || errorWorkflow.WorkspaceID != workflow.WorkspaceID {
+		log.Warn().
+			Str("workflow_id", workflow.ID.String()).
+			Str("error_workflow_id", workflow.ErrorWorkflowID.String()).
+			Msg("Error workflow not found")
+		return
+	}
+
+	errorData := models.JSON{
+		"executionId":  execution.ID.String(),
+		"workflowId":   workflow.ID.String(),
+		"workflowName": workflow.Name,
+		"errorMessage": errMsg,
+		"timedOut":     timedOut,
+		"triggerType":  payload.TriggerType,
+		"input":        payload.InputData,
+		"url":          fmt.Sprintf("%s/executions/%s", e.appURL, execution.ID),
+		"failedAt":     time.Now().UTC().Format(time.RFC3339),
+	}
+	if node := errorNode(result); node != nil {
+		errorData["errorNode"] = node
+	}
+	if details := errorDetails(result); details != nil {
+		errorData["errorDetails"] = details
+	}
+
+	_, err = e.queueClient.EnqueueWorkflowExecution(ctx, queue.WorkflowExecutionPayload{
+		WorkflowID:  errorWorkflow.ID,
+		WorkspaceID: errorWorkflow.WorkspaceID,
+		ExecutionID: uuid.New(),
+		TriggerType: models.TriggerError,
+		TriggerData: models.JSON{
+			"errorExecutionId": execution.ID.String(),
+			"errorWorkflowId":  workflow.ID.String(),
+		},
+		InputData: models.JSON{
+			"$error": errorData,
+			"$json":  errorData,
+		},
+	})
+	if err != nil {
+		log.Error().Err(err).
+			Str("execution_id", execution.ID.String()).
+			Str("error_workflow_id", errorWorkflow.ID.String()).
+			Msg("Failed to enqueue error workflow")
+		return
+	}
+
+	log.Info().
+		Str("execution_id", execution.ID.String()).
+		Str("error_workflow_id", errorWorkflow.ID.String()).
+		Msg("Error workflow triggered")
+}
+
+// errorTriggerMatches reports whether a failure should trigger the error
+// workflow. Without an explicit trigger every failure does.
+func errorTriggerMatches(trigger *string, timedOut bool) bool {
+	if trigger == nil {
+		return true
+	}
+	switch *trigger {
+	case models.ErrorTriggerOnFailure:
+		return !timedOut
+	case models.ErrorTriggerOnTimeout:
+		return timedOut
+	default:
+		return true
+	}
+}
+
+// errorNode describes the node that failed the execution
+func errorNode(result *processor.Result) map[string]interface{} {
+	if result == nil || result.ErrorNodeID == "" {
+		return nil
+	}
+	node := map[string]interface{}{"id": result.ErrorNodeID}
+	if nodeResult, ok := result.NodeResults[result.ErrorNodeID]; ok {
+		node["name"] = nodeResult.NodeName
+		node["type"] = nodeResult.NodeType
+		node["error"] = nodeResult.Error
+	}
+	return node
+}
+
+// errorDetails returns the structured error of a failed execution
+func errorDetails(result *processor.Result) models.JSON {
+	if result == nil || result.ErrorDetails == nil {
+		return nil
+	}
+	return models.JSON(result.ErrorDetails.ToMap())
+}
@@ -3,6 +3,7 @@ package executor
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/linkflow-ai/linkflow/internal/worker/processor"
 	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
 )
 
 // Executor handles workflow execution jobs from the queue
@@ -28,6 +30,13 @@ type Executor struct {
 	cancellation  *processor.CancellationManager
 	credCache     *cache.CredentialCache
 	redis         *redis.Client
+	waitResume    *services.WaitResumeManager
+	queueClient   *queue.Client
+	appURL        string
+	envVarSvc     *services.EnvironmentVariableService
+	environment   string
+
+	stateEncryptor services.Encryptor
 }
 
 // ExecutorConfig configures the executor
@@ -73,6 +82,12 @@ func New(
 	}
 }
 
+// SetWaitResumeManager enables durable waits: executions suspended by a wait
+// node are stored as waiting executions instead of failing
+func (e *Executor) SetWaitResumeManager(mgr *services.WaitResumeManager) {
+	e.waitResume = mgr
+}
+
 // Execute handles a workflow execution job
 func (e *Executor) Execute(ctx context.Context, payload queue.WorkflowExecutionPayload) error {
 	return e.ExecuteWithOptions(ctx, payload, DefaultExecutorConfig())
@@ -81,30 +96,77 @@ func (e *Executor) Execute(ctx context.Context, payload queue.WorkflowExecutionP
 // ExecuteWithOptions handles a workflow execution job with custom options
 func (e *Executor) ExecuteWithOptions(ctx context.Context, payload queue.WorkflowExecutionPayload, cfg ExecutorConfig) error {
 	// Create execution record
-	execution, err := e.executionSvc.Create(ctx, services.CreateExecutionInput{
-		WorkflowID:  payload.WorkflowID,
-		WorkspaceID: payload.WorkspaceID,
-		TriggeredBy: payload.TriggeredBy,
-		TriggerType: payload.TriggerType,
-		TriggerData: payload.TriggerData,
-		InputData:   payload.InputData,
-	})
+	execution, err := e.loadOrCreateExecution(ctx, payload)
 	if err != nil {
 		return fmt.Errorf("failed to create execution: %w", err)
 	}
 
+	// A re-delivered task finds the execution it started: a running one was
+	// interrupted by a worker crash and continues from its checkpoint, any
+	// other was already handled
+	resuming := payload.WaitingID != uuid.Nil
+	recovering := !resuming && execution.Status == models.ExecutionStatusRunning
+	// Paused executions queued to resume continue from the state they paused
+	// with
+	unpausing := !resuming && execution.Status == models.ExecutionStatusQueued && execution.PauseState != nil
+	if !resuming && !recovering && execution.Status != models.ExecutionStatusQueued {
+		log.Warn().
+			Str("execution_id", execution.ID.String()).
+			Str("status", execution.Status).
+			Msg("Skipping re-delivered execution that is no longer queued")
+		return nil
+	}
+
+	release, ok := e.acquireLease(ctx, execution.ID)
+	if !ok {
+		return fmt.Errorf("execution %s is still running on another worker", execution.ID)
+	}
+	defer release()
+
+	// Executions created before they were queued get the priority and queue
+	// they run with. Resumed ones keep those of their first run.
+	priority, taskQueue := queue.NormalizePriority(payload.Priority), queue.QueueForPriority(payload.Priority)
+	if !resuming && !unpausing && (execution.Priority != priority || execution.Queue != taskQueue) {
+		if err := e.executionSvc.RecordQueue(ctx, execution.ID, priority, taskQueue); err != nil {
+			log.Warn().Err(err).Str("execution_id", execution.ID.String()).Msg("Failed to record execution queue")
+		} else {
+			execution.Priority, execution.Queue = priority, taskQueue
+		}
+	}
+
+	// Resumed waits continue the original execution with its trigger
+	if resuming {
+		if execution.Status != models.ExecutionStatusWaiting {
+			log.Warn().
+				Str("execution_id", execution.ID.String()).
+				Str("status", execution.Status).
+				Msg("Skipping resume of execution that is no longer waiting")
+			return nil
+		}
+		payload.TriggeredBy = execution.TriggeredBy
+		payload.TriggerType = execution.TriggerType
+		payload.TriggerData = execution.TriggerData
+		payload.InputData = execution.InputData
+	}
+
 	log.Info().
 		Str("execution_id", execution.ID.String()).
 		Str("workflow_id", payload.WorkflowID.String()).
 		Str("workspace_id", payload.WorkspaceID.String()).
 		Msg("Starting workflow execution")
 
-	// Register for cancellation
+	// Register for cancellation and pausing. Dry runs aren't recorded, so
+	// they can't continue after a pause.
 	execCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	var pause *processor.PauseSignal
 	if e.cancellation != nil {
 		e.cancellation.Register(execution.ID, cancel)
+		if !payload.DryRun {
+			pause = processor.NewPauseSignal()
+			e.cancellation.RegisterPause(ctx, execution.ID, pause.Pause)
+		}
 		defer e.cancellation.Unregister(execution.ID)
 	}
 
@@ -122,8 +184,21 @@ func (e *Executor) ExecuteWithOptions(ctx context.Context, payload queue.Workflo
 		return fmt.Errorf("invalid workflow definition: %w", err)
 	}
 
+	// Workflows with a concurrency limit queue, drop or make room for
+	// executions over it
+	releaseSlot, run, err := e.limitConcurrency(ctx, execution, payload, workflowDef.Settings, resuming || recovering || unpausing)
+	if !run {
+		return err
+	}
+	defer releaseSlot()
+
 	// Start execution
-	if err := e.executionSvc.Start(ctx, execution.ID); err != nil {
+	if resuming || unpausing {
+		err = e.executionSvc.Resume(ctx, execution.ID)
+	} else if !recovering {
+		err = e.executionSvc.Start(ctx, execution.ID)
+	}
+	if err != nil {
 		return err
 	}
 
@@ -145,8 +220,58 @@ func (e *Executor) ExecuteWithOptions(ctx context.Context, payload queue.Workflo
 	opts := processor.ExecutionOptions{
 		MaxParallelNodes:   cfg.MaxParallelNodes,
 		DefaultNodeTimeout: cfg.DefaultNodeTimeout,
-		WorkflowTimeout:    cfg.WorkflowTimeout,
+		WorkflowTimeout:    e.executionTimeout(ctx, execution, workflowDef.Settings, cfg.WorkflowTimeout),
 		EnableCaching:      cfg.EnableCaching,
+		DryRun:             payload.DryRun,
+		Recover:            recovering,
+		Pause:              pause,
+	}
+
+	// Manual and test runs use pinned node data instead of calling those nodes
+	if payload.TriggerType == models.TriggerManual || payload.TriggerType == models.TriggerTest {
+		pinned, err := e.workflowSvc.GetPinnedData(ctx, payload.WorkflowID)
+		if err != nil {
+			log.Warn().Err(err).Str("workflow_id", payload.WorkflowID.String()).Msg("Failed to load pinned data")
+		}
+		opts.PinnedData = pinned
+	}
+
+	// Partial replays re-run from a node on top of the original outputs
+	if payload.TriggerType == models.TriggerPartialReplay {
+		if err := e.applyPartialReplay(ctx, payload, &opts); err != nil {
+			e.handleExecutionError(ctx, execution, payload, err.Error(), nil)
+			return err
+		}
+	}
+
+	env, err := e.loadEnv(ctx, payload.WorkspaceID, workflowDef.Settings)
+	if err != nil {
+		e.handleExecutionError(ctx, execution, payload, "Failed to load environment variables: "+err.Error(), nil)
+		return fmt.Errorf("failed to load environment variables: %w", err)
+	}
+	opts.Env = env
+
+	if resuming {
+		resume, err := e.loadResume(ctx, execution.ID, payload.WaitingID)
+		if err != nil {
+			e.handleExecutionError(ctx, execution, payload, err.Error(), nil)
+			return err
+		}
+		opts.Resume = resume
+	}
+
+	if unpausing {
+		stored, err := e.openState(execution.PauseState)
+		if err != nil {
+			e.handleExecutionError(ctx, execution, payload, err.Error(), nil)
+			return err
+		}
+		state, err := processor.PausedStateFromMap(stored)
+		if err != nil {
+			e.handleExecutionError(ctx, execution, payload, err.Error(), nil)
+			return err
+		}
+		opts.Paused = state
 	}
 
 	// Create credential resolver
@@ -163,21 +288,44 @@ func (e *Executor) ExecuteWithOptions(ctx context.Context, payload queue.Workflo
 		e.publisher,
 	)
 
+	// Executions cancelled while they ran, e.g. to make room for a newer
+	// one, end as cancelled rather than failed
+	if errors.Is(execCtx.Err(), context.Canceled) && ctx.Err() == nil {
+		return e.finishCancelled(ctx, execution, payload, result)
+	}
+
 	// Handle result
 	if err != nil {
 		e.handleExecutionError(ctx, execution, payload, err.Error(), result)
 		return err
 	}
 
+	// Waiting executions are stored and the worker moves on
+	if result.Status == processor.StatusWaiting {
+		return e.suspend(ctx, execution, payload, result)
+	}
+
+	// Paused executions are stored until they are resumed
+	if result.Status == processor.StatusPaused {
+		return e.pause(ctx, execution, payload, result)
+	}
+
 	// Check for processor-level failure
-	if result.Status == processor.StatusFailed {
+	if result.Status == processor.StatusFailed || result.Status == processor.StatusTimedOut {
 		nodeID := &result.ErrorNodeID
 		if result.ErrorNodeID == "" {
 			nodeID = nil
 		}
-		_ = e.executionSvc.Fail(ctx, execution.ID, result.Error, nodeID)
-		e.publishExecutionFailed(ctx, payload.WorkspaceID, payload.WorkflowID, execution.ID, result.Error, nodeID)
-		return fmt.Errorf("workflow failed: %s", result.Error)
+		if result.Status == processor.StatusTimedOut {
+			_ = e.executionSvc.TimeOut(ctx, execution.ID, result.Error, nodeID, errorDetails(result))
+			e.publishExecutionTimedOut(ctx, payload.WorkspaceID, payload.WorkflowID, execution.ID, result.Error, nodeID)
+		} else {
+			_ = e.executionSvc.FailWithDetails(ctx, execution.ID, result.Error, nodeID, errorDetails(result))
+			e.publishExecutionFailed(ctx, payload.WorkspaceID, payload.WorkflowID, execution.ID, result.Error, nodeID)
+		}
+		e.trackUsage(ctx, payload.WorkspaceID, execution.ID, payload.WorkflowID, result, false)
+		e.triggerErrorWorkflow(ctx, execution, payload, result.Error, result)
+		return fmt.Errorf("workflow %s: %s", result.Status, result.Error)
 	}
 
 	if result.Status == processor.StatusCancelled {
@@ -186,8 +334,15 @@ func (e *Executor) ExecuteWithOptions(ctx context.Context, payload queue.Workflo
 		return nil
 	}
 
-	// Complete execution
-	outputJSON := models.JSON(result.Output)
+	// Complete execution, keeping its data only when the workflow saves it
+	var outputJSON models.JSON
+	if saveSuccessfulData(workflowDef.Settings) {
+		outputJSON = models.JSON(result.Output)
+	} else if workflowDef.Settings.SaveExecutionData {
+		if err := e.executionSvc.DiscardNodeData(ctx, execution.ID); err != nil {
+			log.Warn().Err(err).Str("execution_id", execution.ID.String()).Msg("Failed to discard node execution data")
+		}
+	}
 	if err := e.executionSvc.Complete(ctx, execution.ID, outputJSON); err != nil {
 		return err
 	}
@@ -261,25 +416,268 @@ func (e *Executor) createCredentialResolver(ctx context.Context) func(uuid.UUID)
 	}
 }
 
+// loadOrCreateExecution returns the execution record created for the
+// payload when it was enqueued, or by an earlier delivery of the same task.
+// Payloads enqueued without a record get one on their first delivery.
+func (e *Executor) loadOrCreateExecution(ctx context.Context, payload queue.WorkflowExecutionPayload) (*models.Execution, error) {
+	if payload.ExecutionID != uuid.Nil {
+		execution, err := e.executionSvc.GetByID(ctx, payload.ExecutionID)
+		if err == nil {
+			return execution, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("failed to load execution: %w", err)
+		}
+	}
+
+	if payload.ExecutionID == uuid.Nil && payload.TaskID != "" {
+		if id, err := uuid.Parse(e.redis.Get(ctx, taskExecutionKey(payload.TaskID)).Val()); err == nil {
+			if execution, err := e.executionSvc.GetByID(ctx, id); err == nil {
+				return execution, nil
+			}
+		}
+	}
+
+	execution, err := e.executionSvc.Create(ctx, services.CreateExecutionInput{
+		ID:          payload.ExecutionID,
+		WorkflowID:  payload.WorkflowID,
+		WorkspaceID: payload.WorkspaceID,
+		TriggeredBy: payload.TriggeredBy,
+		TriggerType: payload.TriggerType,
+		TriggerData: payload.TriggerData,
+		InputData:   payload.InputData,
+		Priority:    queue.NormalizePriority(payload.Priority),
+		Queue:       queue.QueueForPriority(payload.Priority),
+	})
+	if err != nil {
+		if payload.ExecutionID != uuid.Nil {
+			// A concurrent delivery of the task created it first
+			if existing, getErr := e.executionSvc.GetByID(ctx, payload.ExecutionID); getErr == nil {
+				return existing, nil
+			}
+		}
+		return nil, err
+	}
+
+	if payload.ExecutionID == uuid.Nil && payload.TaskID != "" {
+		if err := e.redis.Set(ctx, taskExecutionKey(payload.TaskID), execution.ID.String(), 24*time.Hour).Err(); err != nil {
+			log.Warn().Err(err).
+				Str("execution_id", execution.ID.String()).
+				Msg("Failed to map task to execution")
+		}
+	}
+	return execution, nil
+}
+
+func taskExecutionKey(taskID string) string {
+	return fmt.Sprintf("execution:task:%s", taskID)
+}
+
+// applyPartialReplay configures a partial run from the replay trigger data
+// and seeds it with the node outputs of the original execution
+func (e *Executor) applyPartialReplay(ctx context.Context, payload queue.WorkflowExecutionPayload, opts *processor.ExecutionOptions) error {
+	startNode, _ := payload.TriggerData["replay_from"].(string)
+	originalID, _ := payload.TriggerData["original_execution"].(string)
+	if startNode == "" || originalID == "" {
+		return fmt.Errorf("partial replay requires replay_from and original_execution")
+	}
+
+	originalExecutionID, err := uuid.Parse(originalID)
+	if err != nil {
+		return fmt.Errorf("invalid original execution ID: %w", err)
+	}
+
+	outputs, err := e.executionSvc.GetNodeOutputs(ctx, originalExecutionID)
+	if err != nil {
+		return fmt.Errorf("failed to load original node outputs: %w", err)
+	}
+
+	opts.StartFromNode = startNode
+	opts.StopAtNode, _ = payload.TriggerData["stop_at"].(string)
+	opts.SeedOutputs = outputs
+	return nil
+}
+
+// suspend stores the state of an execution a node is waiting on
+func (e *Executor) suspend(ctx context.Context, execution *models.Execution, payload queue.WorkflowExecutionPayload, result *processor.Result) error {
+	if e.waitResume == nil {
+		e.handleExecutionError(ctx, execution, payload, "Durable waits are not configured", result)
+		return fmt.Errorf("durable waits are not configured")
+	}
+
+	state, err := e.sealState(result.State)
+	if err != nil {
+		e.handleExecutionError(ctx, execution, payload, err.Error(), result)
+		return err
+	}
+
+	suspension := result.State.Suspension
+	info, err := e.waitResume.CreateWait(ctx, services.WaitInput{
+		ExecutionID:   execution.ID,
+		WorkflowID:    payload.WorkflowID,
+		WorkspaceID:   payload.WorkspaceID,
+		NodeID:        result.State.WaitingNodeID,
+		ResumeType:    suspension.ResumeType,
+		ResumeAt:      suspension.ResumeAt,
+		ResumeToken:   suspension.ResumeToken,
+		ExecutionData: state,
+		Priority:      execution.Priority,
+	})
+	if err != nil {
+		e.handleExecutionError(ctx, execution, payload, err.Error(), result)
+		return err
+	}
+
+	if err := e.executionSvc.Suspend(ctx, execution.ID); err != nil {
+		return err
+	}
+
+	if suspension.Notify != nil {
+		if err := suspension.Notify(ctx); err != nil {
+			return e.withdrawWait(ctx, execution, payload, info, result, err)
+		}
+	}
+
+	log.Info().
+		Str("execution_id", execution.ID.String()).
+		Str("node_id", result.State.WaitingNodeID).
+		Str("resume_type", suspension.ResumeType).
+		Time("resume_at", info.ExpiresAt).
+		Msg("Workflow execution waiting")
+
+	return nil
+}
+
+// withdrawWait fails an execution whose waiting node couldn't hand out its
+// resume URL. The wait is withdrawn first, so whoever did get the URL is told
+// the request expired; if somebody already used it, the execution goes on.
+func (e *Executor) withdrawWait(ctx context.Context, execution *models.Execution, payload queue.WorkflowExecutionPayload, info *services.WaitInfo, result *processor.Result, notifyErr error) error {
+	withdrawn, err := e.waitResume.WithdrawWait(ctx, info.ResumeToken)
+	if err != nil {
+		log.Warn().Err(err).Str("execution_id", execution.ID.String()).Msg("Failed to withdraw wait")
+	} else if !withdrawn {
+		log.Warn().Err(notifyErr).
+			Str("execution_id", execution.ID.String()).
+			Msg("Resume URL partly handed out and already used, execution continues")
+		return nil
+	}
+
+	errMsg := fmt.Sprintf("failed to hand out the resume URL: %s", notifyErr)
+	e.handleExecutionError(ctx, execution, payload, errMsg, result)
+	return notifyErr
+}
+
+// pause stores the state of an execution that paused, so it continues from
+// the nodes it finished when resumed
+func (e *Executor) pause(ctx context.Context, execution *models.Execution, payload queue.WorkflowExecutionPayload, result *processor.Result) error {
+	state, err := e.sealState(result.State)
+	if err != nil {
+		e.handleExecutionError(ctx, execution, payload, err.Error(), result)
+		return err
+	}
+
+	if err := e.executionSvc.Pause(ctx, execution.ID, state); err != nil {
+		if errors.Is(err, services.ErrExecutionNotRunning) {
+			// It was cancelled while its last nodes finished
+			log.Info().
+				Str("execution_id", execution.ID.String()).
+				Msg("Execution no longer running, not pausing it")
+			return nil
+		}
+		return err
+	}
+
+	if e.publisher != nil {
+		_ = e.publisher.ExecutionPaused(ctx, payload.WorkspaceID, payload.WorkflowID, execution.ID, result.NodesExecuted)
+	}
+
+	log.Info().
+		Str("execution_id", execution.ID.String()).
+		Int("nodes_finished", len(result.State.NodeResults)).
+		Msg("Workflow execution paused")
+
+	return nil
+}
+
+// loadResume loads the saved state of a waiting execution that was resumed
+func (e *Executor) loadResume(ctx context.Context, executionID, waitingID uuid.UUID) (*processor.ResumeOptions, error) {
+	if e.waitResume == nil {
+		return nil, fmt.Errorf("durable waits are not configured")
+	}
+
+	waiting, err := e.waitResume.GetWaitingByID(ctx, waitingID)
+	if err != nil {
+		return nil, fmt.Errorf("waiting execution not found: %w", err)
+	}
+	if waiting.ExecutionID != executionID {
+		return nil, fmt.Errorf("waiting execution %s belongs to another execution", waitingID)
+	}
+
+	stored, err := e.openState(waiting.ExecutionData)
+	if err != nil {
+		return nil, err
+	}
+	state, err := processor.ExecutionStateFromMap(stored)
+	if err != nil {
+		return nil, err
+	}
+
+	return &processor.ResumeOptions{
+		State: state,
+		Data:  waiting.ResumeData,
+	}, nil
+}
+
+// finishCancelled ends an execution cancelled while it ran. It's marked
+// cancelled unless whoever cancelled it did; it doesn't trigger the error
+// workflow and its task isn't retried.
+func (e *Executor) finishCancelled(ctx context.Context, execution *models.Execution, payload queue.WorkflowExecutionPayload, result *processor.Result) error {
+	if err := e.executionSvc.Cancel(ctx, execution.ID); err != nil && !errors.Is(err, services.ErrExecutionNotRunning) {
+		log.Warn().Err(err).Str("execution_id", execution.ID.String()).Msg("Failed to mark execution cancelled")
+	}
+	e.trackUsage(ctx, payload.WorkspaceID, execution.ID, payload.WorkflowID, result, false)
+
+	log.Info().
+		Str("execution_id", execution.ID.String()).
+		Msg("Workflow execution cancelled")
+	return nil
+}
+
 func (e *Executor) handleExecutionError(ctx context.Context, execution *models.Execution, payload queue.WorkflowExecutionPayload, errMsg string, result *processor.Result) {
 	var nodeID *string
 	if result != nil && result.ErrorNodeID != "" {
 		nodeID = &result.ErrorNodeID
 	}
 
-	_ = e.executionSvc.Fail(ctx, execution.ID, errMsg, nodeID)
+	_ = e.executionSvc.FailWithDetails(ctx, execution.ID, errMsg, nodeID, errorDetails(result))
 	e.publishExecutionFailed(ctx, payload.WorkspaceID, payload.WorkflowID, execution.ID, errMsg, nodeID)
+	e.triggerErrorWorkflow(ctx, execution, payload, errMsg, result)
 
 	// Track failed execution usage
 	e.trackUsage(ctx, payload.WorkspaceID, execution.ID, payload.WorkflowID, result, false)
 }
 
-// trackUsage updates billing usage after execution
+// trackUsage updates billing usage after execution. Usage is tracked once
+// per execution, however often its task is delivered.
 func (e *Executor) trackUsage(ctx context.Context, workspaceID, executionID, workflowID uuid.UUID, result *processor.Result, success bool) {
 	if e.billingSvc == nil {
 		return
 	}
 
+	claimed, err := e.executionSvc.ClaimUsage(ctx, executionID)
+	if err != nil {
+		log.Warn().Err(err).
+			Str("execution_id", executionID.String()).
+			Msg("Failed to claim execution usage")
+		return
+	}
+	if !claimed {
+		log.Debug().
+			Str("execution_id", executionID.String()).
+			Msg("Usage already tracked")
+		return
+	}
+
 	// Calculate credits based on nodes executed
 	creditsUsed := 1 // Base credit for execution
 	if result != nil {
@@ -367,6 +765,12 @@ func (e *Executor) publishExecutionFailed(ctx context.Context, workspaceID, work
 	}
 }
 
+func (e *Executor) publishExecutionTimedOut(ctx context.Context, workspaceID, workflowID, executionID uuid.UUID, errorMsg string, errorNodeID *string) {
+	if e.publisher != nil {
+		_ = e.publisher.ExecutionTimedOut(ctx, workspaceID, workflowID, executionID, errorMsg, errorNodeID)
+	}
+}
+
 // NodeExecutionError wraps a node-specific error
 type NodeExecutionError struct {
 	NodeID string
@@ -0,0 +1,238 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/linkflow-ai/linkflow/internal/domain/models"
+	"github.com/linkflow-ai/linkflow/internal/domain/services"
+	"github.com/linkflow-ai/linkflow/internal/worker/processor"
+	"github.com/rs/zerolog/log"
+)
+
+// maxRecordAttempts is how often a record is written before it's dropped
+const maxRecordAttempts = 5
+
+// pendingRecord is a queued record and the number of failed writes
+type pendingRecord struct {
+	record   models.NodeExecution
+	attempts int
+}
+
+// NodeExecutionRecorder persists processor node results as NodeExecution
+// records. Records are buffered and written in batches, either when the
+// buffer fills, on a timer, or when an execution finishes, so parallel levels
+// don't issue an insert per node.
+type NodeExecutionRecorder struct {
+	executionSvc  *services.ExecutionService
+	batchSize     int
+	flushInterval time.Duration
+
+	mu      sync.Mutex
+	pending []pendingRecord
+	writeMu sync.Mutex // serializes batch writes so Flush waits for in-flight ones
+
+	flushCh chan struct{}
+	stopCh  chan struct{}
+	doneCh  chan struct{}
+}
+
+// RecorderConfig configures the node execution recorder
+type RecorderConfig struct {
+	BatchSize     int
+	FlushInterval time.Duration
+}
+
+// DefaultRecorderConfig returns default configuration
+func DefaultRecorderConfig() RecorderConfig {
+	return RecorderConfig{
+		BatchSize:     50,
+		FlushInterval: time.Second,
+	}
+}
+
+// NewNodeExecutionRecorder creates a new node execution recorder
+func NewNodeExecutionRecorder(executionSvc *services.ExecutionService, cfg RecorderConfig) *NodeExecutionRecorder {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 50
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = time.Second
+	}
+
+	return &NodeExecutionRecorder{
+		executionSvc:  executionSvc,
+		batchSize:     cfg.BatchSize,
+		flushInterval: cfg.FlushInterval,
+		flushCh:       make(chan struct{}, 1),
+		stopCh:        make(chan struct{}),
+		doneCh:        make(chan struct{}),
+	}
+}
+
+// Start runs the background flush loop until Stop is called
+func (r *NodeExecutionRecorder) Start() {
+	go r.run()
+}
+
+// Stop stops the flush loop and writes any remaining records
+func (r *NodeExecutionRecorder) Stop() {
+	close(r.stopCh)
+	<-r.doneCh
+}
+
+func (r *NodeExecutionRecorder) run() {
+	defer close(r.doneCh)
+
+	ticker := time.NewTicker(r.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stopCh:
+			_ = r.flush(context.Background(), nil)
+			return
+		case <-ticker.C:
+			_ = r.flush(context.Background(), nil)
+		case <-r.flushCh:
+			_ = r.flush(context.Background(), nil)
+		}
+	}
+}
+
+// Record queues a node result for persistence
+func (r *NodeExecutionRecorder) Record(executionID uuid.UUID, result *processor.NodeResult) {
+	r.mu.Lock()
+	r.pending = append(r.pending, pendingRecord{record: toNodeExecution(executionID, result)})
+	full := len(r.pending) >= r.batchSize
+	r.mu.Unlock()
+
+	if full {
+		select {
+		case r.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Flush writes the queued records of an execution. Records that fail to
+// write stay queued for the flush loop.
+func (r *NodeExecutionRecorder) Flush(ctx context.Context, executionID uuid.UUID) error {
+	return r.flush(ctx, &executionID)
+}
+
+// flush writes the queued records of an execution, or all of them when
+// executionID is nil
+func (r *NodeExecutionRecorder) flush(ctx context.Context, executionID *uuid.UUID) error {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+
+	r.mu.Lock()
+	var batch, rest []pendingRecord
+	for _, pending := range r.pending {
+		if executionID == nil || pending.record.ExecutionID == *executionID {
+			batch = append(batch, pending)
+		} else {
+			rest = append(rest, pending)
+		}
+	}
+	r.pending = rest
+	r.mu.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+
+	records := make([]models.NodeExecution, len(batch))
+	for i, pending := range batch {
+		records[i] = pending.record
+	}
+	if err := r.executionSvc.RecordNodeExecutions(ctx, records); err != nil {
+		r.requeue(batch, err)
+		return err
+	}
+	return nil
+}
+
+// requeue puts a batch that failed to write back at the front of the queue.
+// Records are dropped once they failed maxRecordAttempts times.
+func (r *NodeExecutionRecorder) requeue(batch []pendingRecord, err error) {
+	retry := make([]pendingRecord, 0, len(batch))
+	for _, pending := range batch {
+		pending.attempts++
+		if pending.attempts < maxRecordAttempts {
+			retry = append(retry, pending)
+		}
+	}
+
+	r.mu.Lock()
+	r.pending = append(retry, r.pending...)
+	r.mu.Unlock()
+
+	log.Error().Err(err).
+		Int("records", len(batch)).
+		Int("dropped", len(batch)-len(retry)).
+		Msg("Failed to write node executions")
+}
+
+// nodeExecutionID returns the record ID of a node result. Node runs are keyed
+// by their start, so the completion of a node the execution waited on
+// replaces its waiting record and rewritten batches don't insert twice.
+func nodeExecutionID(executionID uuid.UUID, result *processor.NodeResult) uuid.UUID {
+	if result.StartedAt.IsZero() {
+		return uuid.New()
+	}
+	return uuid.NewSHA1(executionID, []byte(fmt.Sprintf("%s/%d", result.NodeID, result.StartedAt.UnixNano())))
+}
+
+// toNodeExecution converts a processor node result into a NodeExecution record
+func toNodeExecution(executionID uuid.UUID, result *processor.NodeResult) models.NodeExecution {
+	nodeName := result.NodeName
+	nodeExec := models.NodeExecution{
+		ID:          nodeExecutionID(executionID, result),
+		ExecutionID: executionID,
+		NodeID:      result.NodeID,
+		NodeType:    result.NodeType,
+		NodeName:    &nodeName,
+		Status:      string(result.Status),
+		RetryCount:  result.Retries,
+		Cached:      result.Cached,
+		Pinned:      result.Pinned,
+		CreatedAt:   time.Now(),
+	}
+
+	// Cache hits are completed runs as far as the node history is concerned
+	if result.Status == processor.NodeStatusCached {
+		nodeExec.Status = models.NodeStatusCompleted
+	}
+
+	if result.Input != nil {
+		nodeExec.InputData = models.JSON(result.Input)
+	}
+	if result.Output != nil {
+		nodeExec.OutputData = models.JSON(result.Output)
+	}
+	if result.Error != "" {
+		errMsg := result.Error
+		nodeExec.ErrorMessage = &errMsg
+	}
+	if result.ErrorDetails != nil {
+		nodeExec.ErrorDetails = models.JSON(result.ErrorDetails.ToMap())
+	}
+
+	if !result.StartedAt.IsZero() {
+		startedAt := result.StartedAt
+		completedAt := result.CompletedAt
+		durationMs := int(result.Duration.Milliseconds())
+		nodeExec.StartedAt = &startedAt
+		nodeExec.CompletedAt = &completedAt
+		nodeExec.DurationMs = &durationMs
+		// Keep the history in execution order although records are inserted in batches
+		nodeExec.CreatedAt = startedAt
+	}
+
+	return nodeExec
+}
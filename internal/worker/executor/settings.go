@@ -0,0 +1,52 @@
+package executor
+
+import (
+	"context"
+	"time"
+
+	"github.com/linkflow-ai/linkflow/internal/domain/models"
+	"github.com/linkflow-ai/linkflow/internal/pkg/queue"
+	"github.com/linkflow-ai/linkflow/internal/worker/processor"
+	"github.com/rs/zerolog/log"
+)
+
+// executionTimeout returns the effective timeout of an execution: the
+// shortest of the workspace plan's, the workflow's and the execution's own.
+// The worker's default applies when none of them is set. It's capped at
+// queue.MaxExecutionTimeout, after which the queue gives up on the task.
+func (e *Executor) executionTimeout(ctx context.Context, execution *models.Execution, settings processor.WorkflowSettings, fallback time.Duration) time.Duration {
+	var timeout time.Duration
+	shorten := func(d time.Duration) {
+		if d > 0 && (timeout == 0 || d < timeout) {
+			timeout = d
+		}
+	}
+
+	if e.billingSvc != nil {
+		limits, err := e.billingSvc.GetPlanLimits(ctx, execution.WorkspaceID)
+		if err != nil {
+			log.Warn().Err(err).
+				Str("workspace_id", execution.WorkspaceID.String()).
+				Msg("Failed to load plan execution timeout")
+		} else {
+			shorten(time.Duration(limits.ExecutionTimeout) * time.Second)
+		}
+	}
+	shorten(settings.ExecutionTimeout)
+	shorten(time.Duration(execution.TimeoutSeconds) * time.Second)
+
+	if timeout == 0 {
+		timeout = fallback
+	}
+	if timeout > queue.MaxExecutionTimeout {
+		timeout = queue.MaxExecutionTimeout
+	}
+	return timeout
+}
+
+// saveSuccessfulData reports whether the data of a successful execution is
+// stored. Node records of executions that don't store it keep their status and
+// timing only.
+func saveSuccessfulData(settings processor.WorkflowSettings) bool {
+	return settings.SaveExecutionData && settings.SaveSuccessfulData
+}
@@ -0,0 +1,72 @@
+package executor
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/linkflow-ai/linkflow/internal/domain/models"
+	"github.com/linkflow-ai/linkflow/internal/domain/services"
+	"github.com/linkflow-ai/linkflow/internal/worker/processor"
+)
+
+// sealedStateKey holds the encrypted state in a stored state column
+const sealedStateKey = "sealed"
+
+// SetStateEncryptor encrypts the state paused and waiting executions are
+// stored with. The state holds the raw node outputs, secrets included.
+func (e *Executor) SetStateEncryptor(encryptor services.Encryptor) {
+	e.stateEncryptor = encryptor
+}
+
+// sealState converts a state for storage in a JSON column, encrypted when a
+// state encryptor is set
+func (e *Executor) sealState(state *processor.ExecutionState) (models.JSON, error) {
+	if e.stateEncryptor == nil {
+		return state.ToMap()
+	}
+	sealed, err := sealData(e.stateEncryptor, state)
+	if err != nil {
+		return nil, err
+	}
+	return models.JSON{sealedStateKey: sealed}, nil
+}
+
+// openState returns the state stored with sealState. States stored without
+// encryption are returned as they are.
+func (e *Executor) openState(stored models.JSON) (map[string]interface{}, error) {
+	sealed, ok := stored[sealedStateKey].(string)
+	if !ok {
+		return stored, nil
+	}
+	var state map[string]interface{}
+	if err := openData(e.stateEncryptor, sealed, &state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func sealData(encryptor services.Encryptor, v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize execution state: %w", err)
+	}
+	sealed, err := encryptor.Encrypt(string(data))
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt execution state: %w", err)
+	}
+	return sealed, nil
+}
+
+func openData(encryptor services.Encryptor, sealed string, v interface{}) error {
+	if encryptor == nil {
+		return fmt.Errorf("execution state is encrypted but no state encryptor is set")
+	}
+	data, err := encryptor.Decrypt(sealed)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt execution state: %w", err)
+	}
+	if err := json.Unmarshal([]byte(data), v); err != nil {
+		return fmt.Errorf("failed to read execution state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package expression
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/ast"
+)
+
+// DefaultCacheSize is the number of compiled programs the shared engine keeps
+const DefaultCacheSize = 4096
+
+var templateRegex = regexp.MustCompile(`\{\{\s*(.+?)\s*\}\}`)
+
+// envVariable holds $env in the environment. expr reserves $env for the
+// environment itself, so expressions have $env rewritten to this name.
+const envVariable = "$__env"
+
+// envPatcher rewrites $env to envVariable
+type envPatcher struct{}
+
+func (envPatcher) Visit(node *ast.Node) {
+	if id, ok := (*node).(*ast.IdentifierNode); ok && id.Value == "$env" {
+		ast.Patch(node, &ast.IdentifierNode{Value: envVariable})
+	}
+}
+
+// Engine evaluates {{ }} templates. Compiled programs are cached by expression
+// and by the shape of the variables they were compiled against, so a template
+// resolved for every node and item is only compiled once.
+type Engine struct {
+	cache *programCache
+}
+
+// NewEngine creates an engine keeping up to cacheSize compiled programs
+func NewEngine(cacheSize int) *Engine {
+	if cacheSize <= 0 {
+		cacheSize = DefaultCacheSize
+	}
+	return &Engine{cache: newProgramCache(cacheSize)}
+}
+
+var shared = NewEngine(DefaultCacheSize)
+
+// Shared returns the engine shared by all executions of the process
+func Shared() *Engine {
+	return shared
+}
+
+// Context contains all variables available in expressions. The variables are
+// captured by the first evaluation; build a new context when they change.
+type Context struct {
+	Input       interface{}
+	JSON        interface{}
+	Node        map[string]interface{}
+	Vars        map[string]interface{}
+	Env         map[string]string
+	Now         time.Time
+	Today       string
+	Timestamp   int64
+	ExecutionID string
+	WorkflowID  string
+	Loop        map[string]interface{}
+	Item        map[string]interface{}
+	ItemIndex   int
+	Prev        map[string]interface{}
+	RunIndex    int
+	Trigger     interface{}
+	Workflow    map[string]interface{}
+
+	env   map[string]interface{}
+	shape string
+}
+
+// environment returns the expression environment and a key describing the
+// variable types, which decide how an expression compiles
+func (c *Context) environment() (map[string]interface{}, string) {
+	if c.env != nil {
+		return c.env, c.shape
+	}
+
+	vars := []struct {
+		name  string
+		value interface{}
+	}{
+		{"$input", c.Input},
+		{"$json", c.JSON},
+		{"$node", c.Node},
+		{"$vars", c.Vars},
+		{envVariable, c.Env},
+		{"$now", c.Now},
+		{"$today", c.Today},
+		{"$timestamp", c.Timestamp},
+		{"$executionId", c.ExecutionID},
+		{"$workflowId", c.WorkflowID},
+		{"$loop", c.Loop},
+		{"$item", c.Item},
+		{"$itemIndex", c.ItemIndex},
+		{"$prev", c.Prev},
+		{"$runIndex", c.RunIndex},
+		{"$trigger", c.Trigger},
+		{"$workflow", c.Workflow},
+	}
+
+	env := make(map[string]interface{}, len(functions)+len(vars))
+	for name, fn := range functions {
+		env[name] = fn
+	}
+
+	var shape strings.Builder
+	for _, v := range vars {
+		env[v.name] = v.value
+		fmt.Fprintf(&shape, "%s:%T;", v.name, v.value)
+	}
+
+	c.env, c.shape = env, shape.String()
+	return c.env, c.shape
+}
+
+// Evaluate evaluates an expression template. A template that is a single
+// expression returns its value; otherwise the results are interpolated.
+func (e *Engine) Evaluate(template string, ctx *Context) (interface{}, error) {
+	if !strings.Contains(template, "{{") {
+		return template, nil
+	}
+
+	// Check if entire template is a single expression
+	if strings.HasPrefix(template, "{{") && strings.HasSuffix(template, "}}") {
+		inner := strings.TrimSpace(template[2 : len(template)-2])
+		if !strings.Contains(inner, "}}") {
+			return e.evaluateExpression(inner, ctx)
+		}
+	}
+
+	// Replace all expressions in template
+	var lastErr error
+	result := templateRegex.ReplaceAllStringFunc(template, func(match string) string {
+		exprStr := templateRegex.FindStringSubmatch(match)[1]
+		val, err := e.evaluateExpression(exprStr, ctx)
+		if err != nil {
+			lastErr = err
+			return match
+		}
+		return fmt.Sprintf("%v", val)
+	})
+
+	if lastErr != nil {
+		return result, lastErr
+	}
+
+	return result, nil
+}
+
+func (e *Engine) evaluateExpression(expression string, ctx *Context) (interface{}, error) {
+	env, shape := ctx.environment()
+
+	key := shape + expression
+	program, ok := e.cache.get(key)
+	if !ok {
+		var err error
+		program, err = expr.Compile(expression, expr.Env(env), expr.Patch(envPatcher{}))
+		if err != nil {
+			return nil, fmt.Errorf("compile error: %w", err)
+		}
+		e.cache.add(key, program)
+	}
+
+	result, err := expr.Run(program, env)
+	if err != nil {
+		return nil, fmt.Errorf("runtime error: %w", err)
+	}
+
+	return result, nil
+}
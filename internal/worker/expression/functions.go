@@ -0,0 +1,652 @@
+package expression
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmespath/go-jmespath"
+	"github.com/oliveagle/jsonpath"
+)
+
+var (
+	slugRegex      = regexp.MustCompile(`[^a-z0-9]+`)
+	wordSplitRegex = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+)
+
+// registry lists the functions available in expressions. The editor's
+// autocomplete is built from the same list.
+var registry = []Function{
+	// String functions
+	{Name: "uppercase", Category: CategoryString, Description: "Converts text to upper case", Func: strings.ToUpper},
+	{Name: "lowercase", Category: CategoryString, Description: "Converts text to lower case", Func: strings.ToLower},
+	{Name: "trim", Category: CategoryString, Description: "Removes leading and trailing whitespace", Func: strings.TrimSpace},
+	{Name: "trimStart", Category: CategoryString, Description: "Removes the given characters from the start of text", Func: func(s, cutset string) string { return strings.TrimLeft(s, cutset) }},
+	{Name: "trimEnd", Category: CategoryString, Description: "Removes the given characters from the end of text", Func: func(s, cutset string) string { return strings.TrimRight(s, cutset) }},
+	{Name: "split", Category: CategoryString, Description: "Splits text into a list at each separator", Func: strings.Split},
+	{Name: "join", Category: CategoryString, Description: "Joins a list of strings with a separator", Func: strings.Join},
+	{Name: "replace", Category: CategoryString, Description: "Replaces every occurrence of a substring", Func: strings.ReplaceAll},
+	{Name: "replaceOne", Category: CategoryString, Description: "Replaces the first occurrence of a substring", Func: func(s, old, new string) string { return strings.Replace(s, old, new, 1) }},
+	{Name: "contains", Category: CategoryString, Description: "Reports whether text contains a substring", Func: strings.Contains},
+	{Name: "startsWith", Category: CategoryString, Description: "Reports whether text starts with a prefix", Func: strings.HasPrefix},
+	{Name: "endsWith", Category: CategoryString, Description: "Reports whether text ends with a suffix", Func: strings.HasSuffix},
+	{Name: "substring", Category: CategoryString, Description: "Returns the text between two indexes", Func: func(s string, start, end int) string {
+		if start < 0 {
+			start = 0
+		}
+		if end > len(s) {
+			end = len(s)
+		}
+		if start > end {
+			return ""
+		}
+		return s[start:end]
+	}},
+	{Name: "length", Category: CategoryString, Description: "Returns the length of a string, list or object", Func: func(v interface{}) int {
+		switch val := v.(type) {
+		case string:
+			return len(val)
+		case []interface{}:
+			return len(val)
+		case map[string]interface{}:
+			return len(val)
+		default:
+			return 0
+		}
+	}},
+	{Name: "padStart", Category: CategoryString, Description: "Pads text at the start to a length", Func: func(s string, length int, pad string) string {
+		for len(s) < length {
+			s = pad + s
+		}
+		return s
+	}},
+	{Name: "padEnd", Category: CategoryString, Description: "Pads text at the end to a length", Func: func(s string, length int, pad string) string {
+		for len(s) < length {
+			s = s + pad
+		}
+		return s
+	}},
+	{Name: "repeat", Category: CategoryString, Description: "Repeats text a number of times", Func: strings.Repeat},
+	{Name: "reverse", Category: CategoryString, Description: "Reverses text", Func: func(s string) string {
+		runes := []rune(s)
+		for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+			runes[i], runes[j] = runes[j], runes[i]
+		}
+		return string(runes)
+	}},
+	{Name: "slug", Category: CategoryString, Description: "Converts text to a URL slug", Func: func(s string) string {
+		s = strings.ToLower(s)
+		s = slugRegex.ReplaceAllString(s, "-")
+		return strings.Trim(s, "-")
+	}},
+	{Name: "camelCase", Category: CategoryString, Description: "Converts text to camelCase", Func: toCamelCase},
+	{Name: "snakeCase", Category: CategoryString, Description: "Converts text to snake_case", Func: toSnakeCase},
+	{Name: "kebabCase", Category: CategoryString, Description: "Converts text to kebab-case", Func: toKebabCase},
+
+	// Array functions
+	{Name: "first", Category: CategoryArray, Description: "Returns the first element of a list", Func: func(arr []interface{}) interface{} {
+		if len(arr) > 0 {
+			return arr[0]
+		}
+		return nil
+	}},
+	{Name: "last", Category: CategoryArray, Description: "Returns the last element of a list", Func: func(arr []interface{}) interface{} {
+		if len(arr) > 0 {
+			return arr[len(arr)-1]
+		}
+		return nil
+	}},
+	{Name: "nth", Category: CategoryArray, Description: "Returns the element at an index of a list", Func: func(arr []interface{}, n int) interface{} {
+		if n >= 0 && n < len(arr) {
+			return arr[n]
+		}
+		return nil
+	}},
+	{Name: "slice", Category: CategoryArray, Description: "Returns the elements between two indexes of a list", Func: func(arr []interface{}, start, end int) []interface{} {
+		if start < 0 {
+			start = 0
+		}
+		if end > len(arr) {
+			end = len(arr)
+		}
+		if start > end {
+			return []interface{}{}
+		}
+		return arr[start:end]
+	}},
+	{Name: "concat", Category: CategoryArray, Description: "Concatenates lists", Func: func(arrs ...[]interface{}) []interface{} {
+		var result []interface{}
+		for _, arr := range arrs {
+			result = append(result, arr...)
+		}
+		return result
+	}},
+	{Name: "flatten", Category: CategoryArray, Description: "Flattens nested lists", Func: flattenArray},
+	{Name: "unique", Category: CategoryArray, Description: "Removes duplicate elements from a list", Func: func(arr []interface{}) []interface{} {
+		seen := make(map[string]bool)
+		var result []interface{}
+		for _, v := range arr {
+			key := fmt.Sprintf("%v", v)
+			if !seen[key] {
+				seen[key] = true
+				result = append(result, v)
+			}
+		}
+		return result
+	}},
+	{Name: "compact", Category: CategoryArray, Description: "Removes empty, null and false elements from a list", Func: func(arr []interface{}) []interface{} {
+		var result []interface{}
+		for _, v := range arr {
+			if v != nil && v != "" && v != false {
+				result = append(result, v)
+			}
+		}
+		return result
+	}},
+	{Name: "includes", Category: CategoryArray, Description: "Reports whether a list contains a value", Func: func(arr []interface{}, val interface{}) bool {
+		for _, v := range arr {
+			if v == val {
+				return true
+			}
+		}
+		return false
+	}},
+	{Name: "indexOf", Category: CategoryArray, Description: "Returns the index of a value in a list, or -1", Func: func(arr []interface{}, val interface{}) int {
+		for i, v := range arr {
+			if v == val {
+				return i
+			}
+		}
+		return -1
+	}},
+	{Name: "pluck", Category: CategoryArray, Description: "Returns a field of every object in a list", Func: func(arr []interface{}, key string) []interface{} {
+		var result []interface{}
+		for _, item := range arr {
+			if obj, ok := item.(map[string]interface{}); ok {
+				result = append(result, obj[key])
+			}
+		}
+		return result
+	}},
+
+	// Math functions
+	{Name: "round", Category: CategoryMath, Description: "Rounds a number to the nearest integer", Func: math.Round},
+	{Name: "floor", Category: CategoryMath, Description: "Rounds a number down", Func: math.Floor},
+	{Name: "ceil", Category: CategoryMath, Description: "Rounds a number up", Func: math.Ceil},
+	{Name: "abs", Category: CategoryMath, Description: "Returns the absolute value of a number", Func: math.Abs},
+	{Name: "min", Category: CategoryMath, Description: "Returns the smaller of two numbers", Func: math.Min},
+	{Name: "max", Category: CategoryMath, Description: "Returns the larger of two numbers", Func: math.Max},
+	{Name: "pow", Category: CategoryMath, Description: "Raises a number to a power", Func: math.Pow},
+	{Name: "sqrt", Category: CategoryMath, Description: "Returns the square root of a number", Func: math.Sqrt},
+	{Name: "log", Category: CategoryMath, Description: "Returns the natural logarithm of a number", Func: math.Log},
+	{Name: "log10", Category: CategoryMath, Description: "Returns the base 10 logarithm of a number", Func: math.Log10},
+	{Name: "exp", Category: CategoryMath, Description: "Returns e raised to a number", Func: math.Exp},
+	{Name: "sin", Category: CategoryMath, Description: "Returns the sine of an angle in radians", Func: math.Sin},
+	{Name: "cos", Category: CategoryMath, Description: "Returns the cosine of an angle in radians", Func: math.Cos},
+	{Name: "tan", Category: CategoryMath, Description: "Returns the tangent of an angle in radians", Func: math.Tan},
+	{Name: "random", Category: CategoryMath, Description: "Returns a random number between 0 and 1", Func: func() float64 { return float64(time.Now().UnixNano()%1000) / 1000 }},
+	{Name: "randInt", Category: CategoryMath, Description: "Returns a random integer between two bounds", Func: func(min, max int) int { return min + int(time.Now().UnixNano()%int64(max-min+1)) }},
+	{Name: "sum", Category: CategoryMath, Description: "Returns the sum of a list of numbers", Func: func(arr []interface{}) float64 {
+		var sum float64
+		for _, v := range arr {
+			sum += toFloat(v)
+		}
+		return sum
+	}},
+	{Name: "avg", Category: CategoryMath, Description: "Returns the average of a list of numbers", Func: func(arr []interface{}) float64 {
+		if len(arr) == 0 {
+			return 0
+		}
+		var sum float64
+		for _, v := range arr {
+			sum += toFloat(v)
+		}
+		return sum / float64(len(arr))
+	}},
+	{Name: "minArr", Category: CategoryMath, Description: "Returns the smallest number of a list", Func: func(arr []interface{}) float64 {
+		if len(arr) == 0 {
+			return 0
+		}
+		min := toFloat(arr[0])
+		for _, v := range arr[1:] {
+			if f := toFloat(v); f < min {
+				min = f
+			}
+		}
+		return min
+	}},
+	{Name: "maxArr", Category: CategoryMath, Description: "Returns the largest number of a list", Func: func(arr []interface{}) float64 {
+		if len(arr) == 0 {
+			return 0
+		}
+		max := toFloat(arr[0])
+		for _, v := range arr[1:] {
+			if f := toFloat(v); f > max {
+				max = f
+			}
+		}
+		return max
+	}},
+
+	// Date functions
+	{Name: "now", Category: CategoryDate, Description: "Returns the current time", Func: func() time.Time { return time.Now() }},
+	{Name: "today", Category: CategoryDate, Description: "Returns the current date as YYYY-MM-DD", Func: func() string { return time.Now().Format("2006-01-02") }},
+	{Name: "timestamp", Category: CategoryDate, Description: "Returns the current Unix timestamp in seconds", Func: func() int64 { return time.Now().Unix() }},
+	{Name: "formatDate", Category: CategoryDate, Description: "Formats a date, e.g. \"YYYY-MM-DD HH:mm\"", Func: func(t time.Time, format string) string {
+		return t.Format(convertDateFormat(format))
+	}},
+	{Name: "parseDate", Category: CategoryDate, Description: "Parses a date in the given format", Func: func(s, format string) time.Time {
+		t, _ := time.Parse(convertDateFormat(format), s)
+		return t
+	}},
+	{Name: "addDays", Category: CategoryDate, Description: "Adds days to a date", Func: func(t time.Time, days int) time.Time {
+		return t.AddDate(0, 0, days)
+	}},
+	{Name: "addMonths", Category: CategoryDate, Description: "Adds months to a date", Func: func(t time.Time, months int) time.Time {
+		return t.AddDate(0, months, 0)
+	}},
+	{Name: "addYears", Category: CategoryDate, Description: "Adds years to a date", Func: func(t time.Time, years int) time.Time {
+		return t.AddDate(years, 0, 0)
+	}},
+	{Name: "addHours", Category: CategoryDate, Description: "Adds hours to a date", Func: func(t time.Time, hours int) time.Time {
+		return t.Add(time.Duration(hours) * time.Hour)
+	}},
+	{Name: "addMinutes", Category: CategoryDate, Description: "Adds minutes to a date", Func: func(t time.Time, mins int) time.Time {
+		return t.Add(time.Duration(mins) * time.Minute)
+	}},
+	{Name: "diffDays", Category: CategoryDate, Description: "Returns the number of days between two dates", Func: func(t1, t2 time.Time) int {
+		return int(t1.Sub(t2).Hours() / 24)
+	}},
+	{Name: "startOfDay", Category: CategoryDate, Description: "Returns the start of a date's day", Func: func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	}},
+	{Name: "endOfDay", Category: CategoryDate, Description: "Returns the end of a date's day", Func: func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 999999999, t.Location())
+	}},
+	{Name: "startOfMonth", Category: CategoryDate, Description: "Returns the start of a date's month", Func: func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	}},
+	{Name: "endOfMonth", Category: CategoryDate, Description: "Returns the end of a date's month", Func: func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month()+1, 0, 23, 59, 59, 999999999, t.Location())
+	}},
+
+	// Object functions
+	{Name: "keys", Category: CategoryObject, Description: "Returns the keys of an object", Func: func(obj map[string]interface{}) []string {
+		keys := make([]string, 0, len(obj))
+		for k := range obj {
+			keys = append(keys, k)
+		}
+		return keys
+	}},
+	{Name: "values", Category: CategoryObject, Description: "Returns the values of an object", Func: func(obj map[string]interface{}) []interface{} {
+		values := make([]interface{}, 0, len(obj))
+		for _, v := range obj {
+			values = append(values, v)
+		}
+		return values
+	}},
+	{Name: "entries", Category: CategoryObject, Description: "Returns the [key, value] pairs of an object", Func: func(obj map[string]interface{}) [][]interface{} {
+		entries := make([][]interface{}, 0, len(obj))
+		for k, v := range obj {
+			entries = append(entries, []interface{}{k, v})
+		}
+		return entries
+	}},
+	{Name: "merge", Category: CategoryObject, Description: "Merges objects, later keys win", Func: func(objs ...map[string]interface{}) map[string]interface{} {
+		result := make(map[string]interface{})
+		for _, obj := range objs {
+			for k, v := range obj {
+				result[k] = v
+			}
+		}
+		return result
+	}},
+	{Name: "pick", Category: CategoryObject, Description: "Returns an object with only the given keys", Func: func(obj map[string]interface{}, keys ...string) map[string]interface{} {
+		result := make(map[string]interface{})
+		for _, k := range keys {
+			if v, ok := obj[k]; ok {
+				result[k] = v
+			}
+		}
+		return result
+	}},
+	{Name: "omit", Category: CategoryObject, Description: "Returns an object without the given keys", Func: func(obj map[string]interface{}, keys ...string) map[string]interface{} {
+		result := make(map[string]interface{})
+		keySet := make(map[string]bool)
+		for _, k := range keys {
+			keySet[k] = true
+		}
+		for k, v := range obj {
+			if !keySet[k] {
+				result[k] = v
+			}
+		}
+		return result
+	}},
+	{Name: "get", Category: CategoryObject, Description: "Returns the value at a dotted path of an object", Func: func(obj interface{}, path string) interface{} {
+		return getNestedValue(obj, path)
+	}},
+	{Name: "set", Category: CategoryObject, Description: "Sets the value at a dotted path of an object", Func: func(obj map[string]interface{}, path string, value interface{}) map[string]interface{} {
+		setNestedValue(obj, path, value)
+		return obj
+	}},
+	{Name: "has", Category: CategoryObject, Description: "Reports whether an object has a key", Func: func(obj map[string]interface{}, key string) bool {
+		_, ok := obj[key]
+		return ok
+	}},
+
+	// Type functions
+	{Name: "isEmpty", Category: CategoryType, Description: "Reports whether a value is null or an empty string, list or object", Func: func(v interface{}) bool {
+		if v == nil {
+			return true
+		}
+		switch val := v.(type) {
+		case string:
+			return val == ""
+		case []interface{}:
+			return len(val) == 0
+		case map[string]interface{}:
+			return len(val) == 0
+		}
+		return false
+	}},
+	{Name: "isNumber", Category: CategoryType, Description: "Reports whether a value is a number", Func: func(v interface{}) bool {
+		switch v.(type) {
+		case int, int64, float64:
+			return true
+		}
+		return false
+	}},
+	{Name: "isString", Category: CategoryType, Description: "Reports whether a value is a string", Func: func(v interface{}) bool {
+		_, ok := v.(string)
+		return ok
+	}},
+	{Name: "isArray", Category: CategoryType, Description: "Reports whether a value is a list", Func: func(v interface{}) bool {
+		_, ok := v.([]interface{})
+		return ok
+	}},
+	{Name: "isObject", Category: CategoryType, Description: "Reports whether a value is an object", Func: func(v interface{}) bool {
+		_, ok := v.(map[string]interface{})
+		return ok
+	}},
+	{Name: "isBoolean", Category: CategoryType, Description: "Reports whether a value is a boolean", Func: func(v interface{}) bool {
+		_, ok := v.(bool)
+		return ok
+	}},
+	{Name: "isNull", Category: CategoryType, Description: "Reports whether a value is null", Func: func(v interface{}) bool {
+		return v == nil
+	}},
+	{Name: "typeof", Category: CategoryType, Description: "Returns the type name of a value", Func: func(v interface{}) string {
+		switch v.(type) {
+		case nil:
+			return "null"
+		case bool:
+			return "boolean"
+		case int, int64, float64:
+			return "number"
+		case string:
+			return "string"
+		case []interface{}:
+			return "array"
+		case map[string]interface{}:
+			return "object"
+		default:
+			return "unknown"
+		}
+	}},
+
+	// Conversion functions
+	{Name: "toString", Category: CategoryConversion, Description: "Converts a value to text", Func: func(v interface{}) string {
+		return fmt.Sprintf("%v", v)
+	}},
+	{Name: "toNumber", Category: CategoryConversion, Description: "Converts a value to a number", Func: func(v interface{}) float64 {
+		return toFloat(v)
+	}},
+	{Name: "toInt", Category: CategoryConversion, Description: "Converts a value to an integer", Func: func(v interface{}) int {
+		return int(toFloat(v))
+	}},
+	{Name: "toBoolean", Category: CategoryConversion, Description: "Converts a value to a boolean", Func: func(v interface{}) bool {
+		switch val := v.(type) {
+		case bool:
+			return val
+		case string:
+			return val != "" && val != "false" && val != "0"
+		case int, int64, float64:
+			return toFloat(v) != 0
+		}
+		return v != nil
+	}},
+	{Name: "toJSON", Category: CategoryConversion, Description: "Serializes a value to JSON", Func: func(v interface{}) string {
+		b, _ := json.Marshal(v)
+		return string(b)
+	}},
+	{Name: "fromJSON", Category: CategoryConversion, Description: "Parses JSON text", Func: func(s string) interface{} {
+		var v interface{}
+		_ = json.Unmarshal([]byte(s), &v)
+		return v
+	}},
+
+	// Utility functions
+	{Name: "ifEmpty", Category: CategoryUtility, Description: "Returns a default when a value is null or empty", Func: func(v, defaultVal interface{}) interface{} {
+		if v == nil || v == "" {
+			return defaultVal
+		}
+		return v
+	}},
+	{Name: "coalesce", Category: CategoryUtility, Description: "Returns the first value that is not null or empty", Func: func(vals ...interface{}) interface{} {
+		for _, v := range vals {
+			if v != nil && v != "" {
+				return v
+			}
+		}
+		return nil
+	}},
+	{Name: "ternary", Category: CategoryUtility, Description: "Returns one of two values depending on a condition", Func: func(cond bool, trueVal, falseVal interface{}) interface{} {
+		if cond {
+			return trueVal
+		}
+		return falseVal
+	}},
+	{Name: "uuid", Category: CategoryUtility, Description: "Generates a random UUID", Func: generateUUID},
+	{Name: "base64Encode", Category: CategoryUtility, Description: "Encodes text as base64", Func: base64Encode},
+	{Name: "base64Decode", Category: CategoryUtility, Description: "Decodes base64 text", Func: base64Decode},
+	{Name: "urlEncode", Category: CategoryUtility, Description: "Encodes text for use in a URL query", Func: urlEncode},
+	{Name: "urlDecode", Category: CategoryUtility, Description: "Decodes URL query text", Func: urlDecode},
+	{Name: "hash", Category: CategoryUtility, Description: "Hashes text with md5, sha1, sha256 or sha512", Func: hashString},
+
+	// JSON functions
+	{Name: "jsonpath", Category: CategoryJSON, Description: "Queries a value with a JSONPath expression, e.g. \"$.orders[*].id\"", Func: jsonPath},
+	{Name: "jmespath", Category: CategoryJSON, Description: "Queries a value with a JMESPath expression, e.g. \"orders[?total > `100`].id\"", Func: jmesPath},
+}
+
+// functions maps the registry to the expression environment
+var functions = func() map[string]interface{} {
+	fns := make(map[string]interface{}, len(registry))
+	for _, fn := range registry {
+		fns[fn.Name] = fn.Func
+	}
+	return fns
+}()
+
+// Helper functions
+func toFloat(v interface{}) float64 {
+	switch val := v.(type) {
+	case int:
+		return float64(val)
+	case int64:
+		return float64(val)
+	case float64:
+		return val
+	case string:
+		f, _ := strconv.ParseFloat(val, 64)
+		return f
+	}
+	return 0
+}
+
+func flattenArray(arr []interface{}) []interface{} {
+	var result []interface{}
+	for _, v := range arr {
+		if nested, ok := v.([]interface{}); ok {
+			result = append(result, flattenArray(nested)...)
+		} else {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func getNestedValue(obj interface{}, path string) interface{} {
+	parts := strings.Split(path, ".")
+	current := obj
+	for _, part := range parts {
+		if m, ok := current.(map[string]interface{}); ok {
+			current = m[part]
+		} else {
+			return nil
+		}
+	}
+	return current
+}
+
+func setNestedValue(obj map[string]interface{}, path string, value interface{}) {
+	parts := strings.Split(path, ".")
+	current := obj
+	for i, part := range parts[:len(parts)-1] {
+		if _, ok := current[part]; !ok {
+			current[part] = make(map[string]interface{})
+		}
+		if next, ok := current[part].(map[string]interface{}); ok {
+			current = next
+		} else {
+			current[part] = make(map[string]interface{})
+			current = current[part].(map[string]interface{})
+		}
+		_ = i
+	}
+	current[parts[len(parts)-1]] = value
+}
+
+func toCamelCase(s string) string {
+	words := wordSplitRegex.Split(s, -1)
+	for i := 1; i < len(words); i++ {
+		if len(words[i]) > 0 {
+			words[i] = strings.ToUpper(words[i][:1]) + strings.ToLower(words[i][1:])
+		}
+	}
+	if len(words[0]) > 0 {
+		words[0] = strings.ToLower(words[0])
+	}
+	return strings.Join(words, "")
+}
+
+func toSnakeCase(s string) string {
+	var result strings.Builder
+	for i, r := range s {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			result.WriteRune('_')
+		}
+		result.WriteRune(r)
+	}
+	return strings.ToLower(result.String())
+}
+
+func toKebabCase(s string) string {
+	return strings.ReplaceAll(toSnakeCase(s), "_", "-")
+}
+
+func convertDateFormat(format string) string {
+	replacements := map[string]string{
+		"YYYY": "2006", "YY": "06",
+		"MM": "01", "M": "1",
+		"DD": "02", "D": "2",
+		"HH": "15", "H": "15",
+		"hh": "03", "h": "3",
+		"mm": "04", "m": "4",
+		"ss": "05", "s": "5",
+		"SSS": "000",
+		"A":   "PM", "a": "pm",
+	}
+	for k, v := range replacements {
+		format = strings.ReplaceAll(format, k, v)
+	}
+	return format
+}
+
+func base64Encode(s string) string {
+	return base64.StdEncoding.EncodeToString([]byte(s))
+}
+
+func base64Decode(s string) string {
+	decoded, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return s
+	}
+	return string(decoded)
+}
+
+func urlEncode(s string) string {
+	return url.QueryEscape(s)
+}
+
+func urlDecode(s string) string {
+	decoded, err := url.QueryUnescape(s)
+	if err != nil {
+		return s
+	}
+	return decoded
+}
+
+func hashString(s, algo string) string {
+	switch strings.ToLower(algo) {
+	case "md5":
+		h := md5.Sum([]byte(s))
+		return hex.EncodeToString(h[:])
+	case "sha1":
+		h := sha1.Sum([]byte(s))
+		return hex.EncodeToString(h[:])
+	case "sha256":
+		h := sha256.Sum256([]byte(s))
+		return hex.EncodeToString(h[:])
+	case "sha512":
+		h := sha512.Sum512([]byte(s))
+		return hex.EncodeToString(h[:])
+	default:
+		h := sha256.Sum256([]byte(s))
+		return hex.EncodeToString(h[:])
+	}
+}
+
+func jsonPath(v interface{}, path string) (interface{}, error) {
+	if !strings.HasPrefix(path, "$") {
+		path = "$." + strings.TrimPrefix(path, ".")
+	}
+	return jsonpath.JsonPathLookup(v, path)
+}
+
+func jmesPath(v interface{}, expression string) (interface{}, error) {
+	return jmespath.Search(expression, v)
+}
+
+func generateUUID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		// Fall back to time-based UUID-like string
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
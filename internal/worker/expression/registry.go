@@ -0,0 +1,93 @@
+package expression
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Function categories
+const (
+	CategoryString     = "string"
+	CategoryArray      = "array"
+	CategoryMath       = "math"
+	CategoryDate       = "date"
+	CategoryObject     = "object"
+	CategoryType       = "type"
+	CategoryConversion = "conversion"
+	CategoryUtility    = "utility"
+	CategoryJSON       = "json"
+)
+
+// Function is a function available in expressions
+type Function struct {
+	Name        string      `json:"name"`
+	Category    string      `json:"category"`
+	Signature   string      `json:"signature"`
+	Description string      `json:"description"`
+	Func        interface{} `json:"-"`
+}
+
+// Variable is a variable available in expressions
+type Variable struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+var variables = []Variable{
+	{"$json", "Workflow input JSON, or the current item's JSON for nodes that run once per item"},
+	{"$input", "Workflow input"},
+	{"$item", "Current input item's JSON (the first item for nodes that run once for all items)"},
+	{"$itemIndex", "Index of the current input item"},
+	{"$node", "Node outputs by node ID, and {json, id, type, runIndex} by node name, e.g. $node[\"Fetch Orders\"].json"},
+	{"$prev", "Previous node: {json, id, name, runIndex}"},
+	{"$runIndex", "How many times the node ran before in this execution, e.g. in a loop"},
+	{"$trigger", "Data of the trigger that started the execution"},
+	{"$workflow", "Workflow metadata: {id, name, workspaceId}"},
+	{"$vars", "Workflow variables"},
+	{"$env", "Environment variables"},
+	{"$loop", "Current loop iteration inside a loop body"},
+	{"$now", "Current time"},
+	{"$today", "Current date as YYYY-MM-DD"},
+	{"$timestamp", "Current Unix timestamp in seconds"},
+	{"$executionId", "Execution ID"},
+	{"$workflowId", "Workflow ID"},
+}
+
+// Functions lists the functions available in expressions, with their
+// signatures, for autocomplete
+func Functions() []Function {
+	fns := make([]Function, len(registry))
+	for i, fn := range registry {
+		fn.Signature = signature(fn.Name, reflect.TypeOf(fn.Func))
+		fns[i] = fn
+	}
+	return fns
+}
+
+// Variables lists the variables available in expressions
+func Variables() []Variable {
+	return append([]Variable(nil), variables...)
+}
+
+// signature renders a function type as name(params) result
+func signature(name string, t reflect.Type) string {
+	params := make([]string, t.NumIn())
+	for i := range params {
+		if t.IsVariadic() && i == t.NumIn()-1 {
+			params[i] = "..." + typeName(t.In(i).Elem())
+		} else {
+			params[i] = typeName(t.In(i))
+		}
+	}
+
+	sig := name + "(" + strings.Join(params, ", ") + ")"
+	if t.NumOut() > 0 {
+		// Errors surface as evaluation errors, not as results
+		sig += " " + typeName(t.Out(0))
+	}
+	return sig
+}
+
+func typeName(t reflect.Type) string {
+	return strings.ReplaceAll(t.String(), "interface {}", "any")
+}
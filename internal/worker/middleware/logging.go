@@ -53,7 +53,7 @@ func (m *LoggingMiddleware) Execute(ctx context.Context, rctx *processor.Runtime
 	startLog := logger.Debug()
 	if m.logInput {
 		input := rctx.PrepareNodeInput(node)
-		startLog = startLog.Interface("input", truncateForLog(input, 500))
+		startLog = startLog.Interface("input", truncateForLog(rctx.Redact(input), 500))
 	}
 	startLog.Msg("Node execution started")
 
@@ -66,7 +66,7 @@ func (m *LoggingMiddleware) Execute(ctx context.Context, rctx *processor.Runtime
 	// Log completion
 	if err != nil {
 		logger.Error().
-			Err(err).
+			Str("error", rctx.RedactString(err.Error())).
 			Dur("duration", duration).
 			Msg("Node execution failed")
 		return nil, err
@@ -74,7 +74,7 @@ func (m *LoggingMiddleware) Execute(ctx context.Context, rctx *processor.Runtime
 
 	endLog := logger.Debug().Dur("duration", duration)
 	if m.logOutput && result != nil {
-		endLog = endLog.Interface("output", truncateForLog(result.Output, 500))
+		endLog = endLog.Interface("output", truncateForLog(rctx.Redact(result.Output), 500))
 	}
 	endLog.Msg("Node execution completed")
 
@@ -151,7 +151,7 @@ func (l *NodeLogger) Debug(msg string, fields ...map[string]interface{}) {
 
 	if len(fields) > 0 {
 		for k, v := range fields[0] {
-			logger = logger.Interface(k, v)
+			logger = logger.Interface(k, l.rctx.Redact(v))
 		}
 	}
 
@@ -166,7 +166,7 @@ func (l *NodeLogger) Info(msg string, fields ...map[string]interface{}) {
 
 	if len(fields) > 0 {
 		for k, v := range fields[0] {
-			logger = logger.Interface(k, v)
+			logger = logger.Interface(k, l.rctx.Redact(v))
 		}
 	}
 
@@ -181,7 +181,7 @@ func (l *NodeLogger) Warn(msg string, fields ...map[string]interface{}) {
 
 	if len(fields) > 0 {
 		for k, v := range fields[0] {
-			logger = logger.Interface(k, v)
+			logger = logger.Interface(k, l.rctx.Redact(v))
 		}
 	}
 
@@ -191,13 +191,15 @@ func (l *NodeLogger) Warn(msg string, fields ...map[string]interface{}) {
 // Error logs an error message
 func (l *NodeLogger) Error(msg string, err error, fields ...map[string]interface{}) {
 	logger := log.Error().
-		Err(err).
 		Str("execution_id", l.rctx.ExecutionID.String()).
 		Str("node_id", l.node.ID)
+	if err != nil {
+		logger = logger.Str("error", l.rctx.RedactString(err.Error()))
+	}
 
 	if len(fields) > 0 {
 		for k, v := range fields[0] {
-			logger = logger.Interface(k, v)
+			logger = logger.Interface(k, l.rctx.Redact(v))
 		}
 	}
 
@@ -41,7 +41,7 @@ func (m *RecoveryMiddleware) Execute(ctx context.Context, rctx *processor.Runtim
 				Str("execution_id", rctx.ExecutionID.String()).
 				Str("node_id", node.ID).
 				Str("node_type", node.Type).
-				Interface("panic", r).
+				Str("panic", rctx.RedactString(fmt.Sprint(r))).
 				Msg("Node execution panicked")
 
 			if m.logStackTrace {
@@ -101,7 +101,7 @@ func (m *RetryOnPanicMiddleware) Execute(ctx context.Context, rctx *processor.Ru
 			Str("execution_id", rctx.ExecutionID.String()).
 			Str("node_id", node.ID).
 			Int("attempt", attempt+1).
-			Interface("panic", panicked).
+			Str("panic", rctx.RedactString(fmt.Sprint(panicked))).
 			Msg("Retrying after panic")
 	}
 
@@ -109,7 +109,7 @@ func (m *RetryOnPanicMiddleware) Execute(ctx context.Context, rctx *processor.Ru
 		Str("execution_id", rctx.ExecutionID.String()).
 		Str("node_id", node.ID).
 		Str("stack", string(lastStack)).
-		Interface("panic", lastPanic).
+		Str("panic", rctx.RedactString(fmt.Sprint(lastPanic))).
 		Msg("Max retries exceeded after panic")
 
 	return nil, fmt.Errorf("node panicked after %d retries: %v", m.maxRetries, lastPanic)
@@ -141,6 +141,33 @@ func (n *HTTPRequestNode) Type() string {
 	return "action.http"
 }
 
+// HasSideEffects reports true for methods other than GET, HEAD and OPTIONS
+func (n *HTTPRequestNode) HasSideEffects(config map[string]interface{}) bool {
+	switch strings.ToUpper(getStringHTTP(config, "method", "GET")) {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return true
+}
+
+// DryRun describes the request the node would send
+func (n *HTTPRequestNode) DryRun(ctx context.Context, execCtx *core.ExecutionContext) (map[string]interface{}, error) {
+	config := execCtx.Config
+
+	return map[string]interface{}{
+		"dryRun": true,
+		"request": map[string]interface{}{
+			"method":      strings.ToUpper(getStringHTTP(config, "method", "GET")),
+			"url":         getStringHTTP(config, "url", ""),
+			"headers":     getMapHTTP(config, "headers"),
+			"queryParams": getMapHTTP(config, "queryParams"),
+			"bodyType":    getStringHTTP(config, "bodyType", "json"),
+			"body":        config["body"],
+			"authType":    getStringHTTP(config, "authType", "none"),
+		},
+	}, nil
+}
+
 func (n *HTTPRequestNode) Execute(ctx context.Context, execCtx *core.ExecutionContext) (map[string]interface{}, error) {
 	config := execCtx.Config
 
@@ -159,7 +186,7 @@ func (n *HTTPRequestNode) Execute(ctx context.Context, execCtx *core.ExecutionCo
 	if len(queryParams) > 0 {
 		u, err := url.Parse(urlStr)
 		if err != nil {
-			return nil, fmt.Errorf("invalid URL: %w", err)
+			return nil, core.NewConfigError("invalid URL", err)
 		}
 		q := u.Query()
 		for k, v := range queryParams {
@@ -171,7 +198,7 @@ func (n *HTTPRequestNode) Execute(ctx context.Context, execCtx *core.ExecutionCo
 
 	// SECURITY: Check for SSRF attacks before making request
 	if err := isBlockedURL(urlStr); err != nil {
-		return nil, fmt.Errorf("SSRF protection: %w", err)
+		return nil, core.NewConfigError("SSRF protection", err)
 	}
 
 	// Build request body
@@ -223,7 +250,7 @@ func (n *HTTPRequestNode) Execute(ctx context.Context, execCtx *core.ExecutionCo
 							} else if filePath, ok := val["path"].(string); ok {
 								// SECURITY: Validate file path to prevent path traversal
 								if err := validateFilePath(filePath); err != nil {
-									return nil, fmt.Errorf("path traversal protection: %w", err)
+									return nil, core.NewConfigError("path traversal protection", err)
 								}
 								// Read from file path
 								fileContent, err := os.ReadFile(filePath)
@@ -261,7 +288,7 @@ func (n *HTTPRequestNode) Execute(ctx context.Context, execCtx *core.ExecutionCo
 				if filePath, ok := val["path"].(string); ok {
 					// SECURITY: Validate file path to prevent path traversal
 					if err := validateFilePath(filePath); err != nil {
-						return nil, fmt.Errorf("path traversal protection: %w", err)
+						return nil, core.NewConfigError("path traversal protection", err)
 					}
 					fileContent, err := os.ReadFile(filePath)
 					if err != nil {
@@ -292,7 +319,7 @@ func (n *HTTPRequestNode) Execute(ctx context.Context, execCtx *core.ExecutionCo
 	// Create request
 	req, err := http.NewRequestWithContext(ctx, method, urlStr, reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, core.NewConfigError("failed to create request", err)
 	}
 
 	// Set headers
@@ -389,14 +416,14 @@ func (n *HTTPRequestNode) Execute(ctx context.Context, execCtx *core.ExecutionCo
 	duration := time.Since(startTime).Milliseconds()
 
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, core.NewRequestError("request failed", err)
 	}
 	defer resp.Body.Close()
 
 	// Read response body
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, core.NewRequestError("failed to read response", err)
 	}
 
 	// Parse response headers
@@ -425,7 +452,7 @@ func (n *HTTPRequestNode) Execute(ctx context.Context, execCtx *core.ExecutionCo
 
 	// Check for error status codes if configured
 	if getBoolHTTP(config, "throwOnError", false) && resp.StatusCode >= 400 {
-		return result, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+		return result, core.NewAPIError("HTTP", resp, respBody, "HTTP "+resp.Status)
 	}
 
 	return result, nil
@@ -30,6 +30,11 @@ func (n *SubWorkflowNode) Type() string {
 	return "action.sub_workflow"
 }
 
+// HasSideEffects reports true: the node starts another execution
+func (n *SubWorkflowNode) HasSideEffects(config map[string]interface{}) bool {
+	return true
+}
+
 func (n *SubWorkflowNode) Execute(ctx context.Context, execCtx *core.ExecutionContext) (map[string]interface{}, error) {
 	config := execCtx.Config
 	input := execCtx.Input
@@ -69,6 +74,7 @@ func (n *SubWorkflowNode) Execute(ctx context.Context, execCtx *core.ExecutionCo
 	payload := queue.WorkflowExecutionPayload{
 		WorkflowID:  workflowID,
 		WorkspaceID: execCtx.WorkspaceID,
+		ExecutionID: uuid.New(),
 		TriggerType: models.TriggerSubWorkflow,
 		InputData:   inputData,
 		TriggerData: models.JSON{
@@ -183,6 +189,11 @@ func (n *ExecuteWorkflowNode) Type() string {
 	return "action.execute_workflow"
 }
 
+// HasSideEffects reports true: the node starts another execution
+func (n *ExecuteWorkflowNode) HasSideEffects(config map[string]interface{}) bool {
+	return true
+}
+
 func (n *ExecuteWorkflowNode) Execute(ctx context.Context, execCtx *core.ExecutionContext) (map[string]interface{}, error) {
 	config := execCtx.Config
 	input := execCtx.Input
@@ -220,6 +231,7 @@ func (n *ExecuteWorkflowNode) Execute(ctx context.Context, execCtx *core.Executi
 	payload := queue.WorkflowExecutionPayload{
 		WorkflowID:  workflowID,
 		WorkspaceID: execCtx.WorkspaceID,
+		ExecutionID: uuid.New(),
 		TriggerType: models.TriggerSubWorkflow,
 		InputData:   inputData,
 		TriggerData: models.JSON{
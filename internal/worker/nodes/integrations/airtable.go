@@ -271,13 +271,13 @@ func (n *AirtableNode) makeRequest(ctx context.Context, method, endpoint string,
 	client := &http.Client{Timeout: 30 * time.Second}
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, core.NewRequestError("request failed", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, core.NewRequestError("failed to read response", err)
 	}
 
 	var result map[string]interface{}
@@ -292,7 +292,7 @@ func (n *AirtableNode) makeRequest(ctx context.Context, method, endpoint string,
 				errMsg = msg
 			}
 		}
-		return nil, fmt.Errorf("%s (status %d)", errMsg, resp.StatusCode)
+		return nil, core.NewAPIError("Airtable", resp, respBody, fmt.Sprintf("%s (status %d)", errMsg, resp.StatusCode))
 	}
 
 	return result, nil
@@ -24,12 +24,12 @@ func (n *AnthropicNode) Type() string {
 func (n *AnthropicNode) Execute(ctx context.Context, execCtx *core.ExecutionContext) (map[string]interface{}, error) {
 	credID := getString(execCtx.Config, "credentialId", "")
 	if credID == "" {
-		return nil, fmt.Errorf("credential ID is required")
+		return nil, core.NewCredentialError("credential ID is required", nil)
 	}
 
 	cred, err := execCtx.GetCredential(parseUUID(credID))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get credential: %w", err)
+		return nil, core.NewCredentialError("failed to get credential", err)
 	}
 
 	apiKey := cred.APIKey
@@ -142,9 +142,9 @@ func (n *AnthropicNode) makeRequest(ctx context.Context, apiKey, url string, pay
 		var errResp map[string]interface{}
 		_ = json.Unmarshal(body, &errResp)
 		if errMsg, ok := errResp["error"].(map[string]interface{}); ok {
-			return nil, fmt.Errorf("Anthropic API error: %v", errMsg["message"])
+			return nil, core.NewAPIError("Anthropic", resp, body, fmt.Sprintf("Anthropic API error: %v", errMsg["message"]))
 		}
-		return nil, fmt.Errorf("Anthropic API error: %s", string(body))
+		return nil, core.NewAPIError("Anthropic", resp, body, "")
 	}
 
 	var result map[string]interface{}
@@ -31,17 +31,17 @@ func (n *DiscordNode) Execute(ctx context.Context, execCtx *core.ExecutionContex
 		if webhookURL := getString(config, "webhookUrl", ""); webhookURL != "" {
 			return n.sendWebhook(ctx, webhookURL, config)
 		}
-		return nil, fmt.Errorf("credential or webhook URL is required")
+		return nil, core.NewCredentialError("credential or webhook URL is required", nil)
 	}
 
 	credID, err := uuid.Parse(credIDStr)
 	if err != nil {
-		return nil, fmt.Errorf("invalid credential ID")
+		return nil, core.NewCredentialError("invalid credential ID", nil)
 	}
 
 	cred, err := execCtx.GetCredential(credID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get credential: %w", err)
+		return nil, core.NewCredentialError("failed to get credential", err)
 	}
 
 	token := cred.Token
@@ -158,7 +158,7 @@ func (n *DiscordNode) sendWebhook(ctx context.Context, webhookURL string, config
 
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("webhook error %d: %s", resp.StatusCode, string(body))
+		return nil, core.NewAPIError("Discord", resp, body, fmt.Sprintf("webhook error %d", resp.StatusCode))
 	}
 
 	return map[string]interface{}{
@@ -250,7 +250,7 @@ func (n *DiscordNode) makeRequest(ctx context.Context, token, method, url string
 	if resp.StatusCode >= 400 {
 		var errResp map[string]interface{}
 		_ = json.Unmarshal(respBody, &errResp)
-		return errResp, fmt.Errorf("Discord API error %d: %s", resp.StatusCode, string(respBody))
+		return errResp, core.NewAPIError("Discord", resp, respBody, fmt.Sprintf("Discord API error %d", resp.StatusCode))
 	}
 
 	var result map[string]interface{}
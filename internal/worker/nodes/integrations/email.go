@@ -35,17 +35,17 @@ func (n *EmailNode) sendEmail(ctx context.Context, execCtx *core.ExecutionContex
 	// Get SMTP credential
 	credIDStr := getString(config, "credentialId", "")
 	if credIDStr == "" {
-		return nil, fmt.Errorf("credential is required")
+		return nil, core.NewCredentialError("credential is required", nil)
 	}
 
 	credID, err := uuid.Parse(credIDStr)
 	if err != nil {
-		return nil, fmt.Errorf("invalid credential ID")
+		return nil, core.NewCredentialError("invalid credential ID", nil)
 	}
 
 	cred, err := execCtx.GetCredential(credID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get credential: %w", err)
+		return nil, core.NewCredentialError("failed to get credential", err)
 	}
 
 	// SMTP config from credential
@@ -120,17 +120,17 @@ func (n *EmailNode) sendHTMLEmail(ctx context.Context, execCtx *core.ExecutionCo
 	// Get SMTP credential
 	credIDStr := getString(config, "credentialId", "")
 	if credIDStr == "" {
-		return nil, fmt.Errorf("credential is required")
+		return nil, core.NewCredentialError("credential is required", nil)
 	}
 
 	credID, err := uuid.Parse(credIDStr)
 	if err != nil {
-		return nil, fmt.Errorf("invalid credential ID")
+		return nil, core.NewCredentialError("invalid credential ID", nil)
 	}
 
 	cred, err := execCtx.GetCredential(credID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get credential: %w", err)
+		return nil, core.NewCredentialError("failed to get credential", err)
 	}
 
 	host := getString(config, "host", "")
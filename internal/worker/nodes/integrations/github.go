@@ -24,12 +24,12 @@ func (n *GitHubNode) Type() string {
 func (n *GitHubNode) Execute(ctx context.Context, execCtx *core.ExecutionContext) (map[string]interface{}, error) {
 	credID := getString(execCtx.Config, "credentialId", "")
 	if credID == "" {
-		return nil, fmt.Errorf("credential ID is required")
+		return nil, core.NewCredentialError("credential ID is required", nil)
 	}
 
 	cred, err := execCtx.GetCredential(parseUUID(credID))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get credential: %w", err)
+		return nil, core.NewCredentialError("failed to get credential", err)
 	}
 
 	token := cred.Token
@@ -307,7 +307,7 @@ func (n *GitHubNode) makeRequest(ctx context.Context, token, method, url string,
 	respBody, _ := io.ReadAll(resp.Body)
 
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("GitHub API error: %s - %s", resp.Status, string(respBody))
+		return nil, core.NewAPIError("GitHub", resp, respBody, "")
 	}
 
 	var result interface{}
@@ -243,7 +243,7 @@ func (n *GoogleDriveNode) uploadFile(ctx context.Context, config map[string]inte
 	_ = json.Unmarshal(respBody, &result)
 
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("upload failed: %v", result)
+		return nil, core.NewAPIError("Google Drive", resp, respBody, fmt.Sprintf("upload failed: %s", resp.Status))
 	}
 
 	return map[string]interface{}{
@@ -307,7 +307,7 @@ func (n *GoogleDriveNode) updateFile(ctx context.Context, config map[string]inte
 	_ = json.Unmarshal(respBody, &result)
 
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("update failed: %v", result)
+		return nil, core.NewAPIError("Google Drive", resp, respBody, fmt.Sprintf("update failed: %s", resp.Status))
 	}
 
 	return map[string]interface{}{
@@ -341,7 +341,7 @@ func (n *GoogleDriveNode) deleteFile(ctx context.Context, config map[string]inte
 
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("delete failed: %s", string(body))
+		return nil, core.NewAPIError("Google Drive", resp, body, fmt.Sprintf("delete failed: %s", resp.Status))
 	}
 
 	return map[string]interface{}{
@@ -539,13 +539,13 @@ func (n *GoogleDriveNode) makeRequest(ctx context.Context, method, endpoint stri
 	client := &http.Client{Timeout: 30 * time.Second}
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, core.NewRequestError("request failed", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, core.NewRequestError("failed to read response", err)
 	}
 
 	var result map[string]interface{}
@@ -554,7 +554,7 @@ func (n *GoogleDriveNode) makeRequest(ctx context.Context, method, endpoint stri
 	}
 
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("API error: %v", result)
+		return nil, core.NewAPIError("Google Drive", resp, respBody, "")
 	}
 
 	return result, nil
@@ -26,17 +26,17 @@ func (n *GoogleSheetsNode) Execute(ctx context.Context, execCtx *core.ExecutionC
 	// Get credential
 	credIDStr := getString(config, "credentialId", "")
 	if credIDStr == "" {
-		return nil, fmt.Errorf("Google OAuth credential is required")
+		return nil, core.NewCredentialError("Google OAuth credential is required", nil)
 	}
 
 	credID, err := uuid.Parse(credIDStr)
 	if err != nil {
-		return nil, fmt.Errorf("invalid credential ID")
+		return nil, core.NewCredentialError("invalid credential ID", nil)
 	}
 
 	cred, err := execCtx.GetCredential(credID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get credential: %w", err)
+		return nil, core.NewCredentialError("failed to get credential", err)
 	}
 
 	accessToken := cred.AccessToken
@@ -82,14 +82,14 @@ func (n *GoogleSheetsNode) readSheet(ctx context.Context, config map[string]inte
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, core.NewRequestError("request failed", err)
 	}
 	defer resp.Body.Close()
 
 	body, _ := io.ReadAll(resp.Body)
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s", string(body))
+		return nil, core.NewAPIError("Google Sheets", resp, body, "")
 	}
 
 	var result map[string]interface{}
@@ -171,14 +171,14 @@ func (n *GoogleSheetsNode) appendToSheet(ctx context.Context, config map[string]
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, core.NewRequestError("request failed", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, _ := io.ReadAll(resp.Body)
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s", string(respBody))
+		return nil, core.NewAPIError("Google Sheets", resp, respBody, "")
 	}
 
 	var result map[string]interface{}
@@ -220,14 +220,14 @@ func (n *GoogleSheetsNode) updateSheet(ctx context.Context, config map[string]in
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, core.NewRequestError("request failed", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, _ := io.ReadAll(resp.Body)
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s", string(respBody))
+		return nil, core.NewAPIError("Google Sheets", resp, respBody, "")
 	}
 
 	var result map[string]interface{}
@@ -256,14 +256,14 @@ func (n *GoogleSheetsNode) clearSheet(ctx context.Context, config map[string]int
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, core.NewRequestError("request failed", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, _ := io.ReadAll(resp.Body)
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s", string(respBody))
+		return nil, core.NewAPIError("Google Sheets", resp, respBody, "")
 	}
 
 	return map[string]interface{}{
@@ -295,14 +295,14 @@ func (n *GoogleSheetsNode) createSpreadsheet(ctx context.Context, config map[str
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, core.NewRequestError("request failed", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, _ := io.ReadAll(resp.Body)
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s", string(respBody))
+		return nil, core.NewAPIError("Google Sheets", resp, respBody, "")
 	}
 
 	var result map[string]interface{}
@@ -330,14 +330,14 @@ func (n *GoogleSheetsNode) getSheets(ctx context.Context, config map[string]inte
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, core.NewRequestError("request failed", err)
 	}
 	defer resp.Body.Close()
 
 	body, _ := io.ReadAll(resp.Body)
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s", string(body))
+		return nil, core.NewAPIError("Google Sheets", resp, body, "")
 	}
 
 	var result map[string]interface{}
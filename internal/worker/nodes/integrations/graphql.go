@@ -89,13 +89,13 @@ func (n *GraphQLNode) Execute(ctx context.Context, execCtx *core.ExecutionContex
 	client := &http.Client{Timeout: time.Duration(timeout) * time.Second}
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, core.NewRequestError("request failed", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, core.NewRequestError("failed to read response", err)
 	}
 
 	var result map[string]interface{}
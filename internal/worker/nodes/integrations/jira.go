@@ -434,13 +434,13 @@ func (n *JiraNode) makeRequest(ctx context.Context, method, endpoint string, bod
 	client := &http.Client{Timeout: 30 * time.Second}
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, core.NewRequestError("request failed", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, core.NewRequestError("failed to read response", err)
 	}
 
 	// Handle empty response (204 No Content)
@@ -463,7 +463,7 @@ func (n *JiraNode) makeRequest(ctx context.Context, method, endpoint string, bod
 		if errMsgs == nil {
 			errMsgs = result["errors"]
 		}
-		return nil, fmt.Errorf("Jira API error (status %d): %v", resp.StatusCode, errMsgs)
+		return nil, core.NewAPIError("Jira", resp, respBody, fmt.Sprintf("Jira API error (status %d): %v", resp.StatusCode, errMsgs))
 	}
 
 	return result, nil
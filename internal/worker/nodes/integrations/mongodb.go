@@ -28,17 +28,17 @@ func (n *MongoDBNode) Execute(ctx context.Context, execCtx *core.ExecutionContex
 	// Get credential
 	credIDStr := getString(config, "credentialId", "")
 	if credIDStr == "" {
-		return nil, fmt.Errorf("MongoDB credential is required")
+		return nil, core.NewCredentialError("MongoDB credential is required", nil)
 	}
 
 	credID, err := uuid.Parse(credIDStr)
 	if err != nil {
-		return nil, fmt.Errorf("invalid credential ID")
+		return nil, core.NewCredentialError("invalid credential ID", nil)
 	}
 
 	cred, err := execCtx.GetCredential(credID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get credential: %w", err)
+		return nil, core.NewCredentialError("failed to get credential", err)
 	}
 
 	client, err := n.connect(ctx, cred.Data)
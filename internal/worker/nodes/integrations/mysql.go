@@ -54,17 +54,17 @@ func (n *MySQLNode) Execute(ctx context.Context, execCtx *core.ExecutionContext)
 	// Get credential
 	credIDStr := getString(config, "credentialId", "")
 	if credIDStr == "" {
-		return nil, fmt.Errorf("MySQL credential is required")
+		return nil, core.NewCredentialError("MySQL credential is required", nil)
 	}
 
 	credID, err := uuid.Parse(credIDStr)
 	if err != nil {
-		return nil, fmt.Errorf("invalid credential ID")
+		return nil, core.NewCredentialError("invalid credential ID", nil)
 	}
 
 	cred, err := execCtx.GetCredential(credID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get credential: %w", err)
+		return nil, core.NewCredentialError("failed to get credential", err)
 	}
 
 	db, err := n.connect(cred.Data)
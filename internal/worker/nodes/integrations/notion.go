@@ -374,13 +374,13 @@ func (n *NotionNode) makeRequest(ctx context.Context, method, endpoint string, b
 	client := &http.Client{Timeout: 30 * time.Second}
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, core.NewRequestError("request failed", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, core.NewRequestError("failed to read response", err)
 	}
 
 	var result map[string]interface{}
@@ -393,7 +393,7 @@ func (n *NotionNode) makeRequest(ctx context.Context, method, endpoint string, b
 		if msg, ok := result["message"].(string); ok {
 			errMsg = msg
 		}
-		return nil, fmt.Errorf("%s (status %d)", errMsg, resp.StatusCode)
+		return nil, core.NewAPIError("Notion", resp, respBody, fmt.Sprintf("%s (status %d)", errMsg, resp.StatusCode))
 	}
 
 	return result, nil
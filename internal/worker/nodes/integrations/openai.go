@@ -29,17 +29,17 @@ func (n *OpenAINode) Execute(ctx context.Context, execCtx *core.ExecutionContext
 	// Get credential
 	credIDStr := getString(config, "credentialId", "")
 	if credIDStr == "" {
-		return nil, fmt.Errorf("credential is required")
+		return nil, core.NewCredentialError("credential is required", nil)
 	}
 
 	credID, err := uuid.Parse(credIDStr)
 	if err != nil {
-		return nil, fmt.Errorf("invalid credential ID")
+		return nil, core.NewCredentialError("invalid credential ID", nil)
 	}
 
 	cred, err := execCtx.GetCredential(credID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get credential: %w", err)
+		return nil, core.NewCredentialError("failed to get credential", err)
 	}
 
 	apiKey := cred.APIKey
@@ -408,9 +408,9 @@ func (n *OpenAINode) makeMultipartRequest(ctx context.Context, apiKey, url strin
 
 	if resp.StatusCode >= 400 {
 		if errObj, ok := result["error"].(map[string]interface{}); ok {
-			return result, fmt.Errorf("OpenAI API error: %s", errObj["message"])
+			return result, core.NewAPIError("OpenAI", resp, respBody, fmt.Sprintf("OpenAI API error: %s", errObj["message"]))
 		}
-		return result, fmt.Errorf("OpenAI API error: %d", resp.StatusCode)
+		return result, core.NewAPIError("OpenAI", resp, respBody, "")
 	}
 
 	// Extract data for convenience
@@ -482,9 +482,9 @@ func (n *OpenAINode) makeRequest(ctx context.Context, apiKey, method, url string
 
 	if resp.StatusCode >= 400 {
 		if errObj, ok := result["error"].(map[string]interface{}); ok {
-			return result, fmt.Errorf("OpenAI API error: %s", errObj["message"])
+			return result, core.NewAPIError("OpenAI", resp, respBody, fmt.Sprintf("OpenAI API error: %s", errObj["message"]))
 		}
-		return result, fmt.Errorf("OpenAI API error: %d", resp.StatusCode)
+		return result, core.NewAPIError("OpenAI", resp, respBody, "")
 	}
 
 	return result, nil
@@ -259,13 +259,13 @@ func (n *SalesforceNode) makeRequest(ctx context.Context, method, endpoint strin
 	client := &http.Client{Timeout: 30 * time.Second}
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, core.NewRequestError("request failed", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, core.NewRequestError("failed to read response", err)
 	}
 
 	// Handle empty response (204 No Content)
@@ -291,7 +291,7 @@ func (n *SalesforceNode) makeRequest(ctx context.Context, method, endpoint strin
 		if errs, ok := result["errors"].([]interface{}); ok && len(errs) > 0 {
 			errMsg = fmt.Sprintf("%v", errs)
 		}
-		return nil, fmt.Errorf("%s (status %d)", errMsg, resp.StatusCode)
+		return nil, core.NewAPIError("Salesforce", resp, respBody, fmt.Sprintf("%s (status %d)", errMsg, resp.StatusCode))
 	}
 
 	return result, nil
@@ -0,0 +1,143 @@
+package integrations
+
+import (
+	"strings"
+)
+
+// readOnlyOperations lists, per integration, the operations that only read
+// data. Every other operation changes external state and is simulated
+// instead of executed in dry-run mode.
+var readOnlyOperations = map[string][]string{
+	"integrations.airtable":     {"list", "get", "search", "listBases", "listTables"},
+	"integrations.aws_s3":       {"list", "get", "getSignedUrl", "listBuckets"},
+	"integration.discord":       {"getChannel", "listChannels", "getUser", "getGuild"},
+	"integrations.ftp":          {"list", "download"},
+	"integrations.sftp":         {"list", "download"},
+	"integration.github":        {"getRepo", "listRepos", "getIssue", "listIssues", "getPR", "listPRs", "getUser", "listBranches", "getFile"},
+	"integrations.google_drive": {"list", "get", "download", "search"},
+	"integration.googleSheets":  {"read", "getSheets"},
+	"integrations.jira":         {"getIssue", "searchIssues", "getComments", "getProjects", "getProject", "getTransitions", "getUsers"},
+	"integration.mongodb":       {"find", "findOne", "aggregate", "count"},
+	"integrations.notion":       {"getPage", "getDatabase", "queryDatabase", "getBlock", "getBlockChildren", "search", "getUser", "listUsers"},
+	"integrations.salesforce":   {"query", "get", "describe", "describeGlobal", "search"},
+	"integrations.sendgrid":     {"getContacts", "getLists"},
+	"integration.slack":         {"getChannel", "listChannels", "getUser", "listUsers"},
+	"integration.stripe":        {"list", "get"},
+	"integration.telegram":      {"getChat", "getChatMember", "getChatMemberCount", "getMe"},
+	"integrations.twilio":       {"getMessages", "getMessage", "getCalls", "lookupNumber"},
+}
+
+// operationHasSideEffects reports whether the configured operation of an
+// integration is missing from its read-only list
+func operationHasSideEffects(nodeType string, config map[string]interface{}, defaultOperation string) bool {
+	operation := getString(config, "operation", defaultOperation)
+	for _, readOnly := range readOnlyOperations[nodeType] {
+		if operation == readOnly {
+			return false
+		}
+	}
+	return true
+}
+
+// sqlHasSideEffects reports whether a SQL node would modify the database. Raw
+// queries count as reads only when they start with a read-only statement.
+func sqlHasSideEffects(config map[string]interface{}) bool {
+	operation := getString(config, "operation", "query")
+	if operation != "query" {
+		return true
+	}
+
+	query := strings.ToUpper(strings.TrimSpace(getString(config, "query", "")))
+	for _, prefix := range []string{"SELECT", "WITH", "SHOW", "EXPLAIN", "DESCRIBE"} {
+		if strings.HasPrefix(query, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *AirtableNode) HasSideEffects(config map[string]interface{}) bool {
+	return operationHasSideEffects(n.Type(), config, "list")
+}
+
+func (n *AWSS3Node) HasSideEffects(config map[string]interface{}) bool {
+	return operationHasSideEffects(n.Type(), config, "list")
+}
+
+func (n *DiscordNode) HasSideEffects(config map[string]interface{}) bool {
+	return operationHasSideEffects(n.Type(), config, "sendMessage")
+}
+
+func (n *EmailNode) HasSideEffects(config map[string]interface{}) bool {
+	return true
+}
+
+func (n *FTPNode) HasSideEffects(config map[string]interface{}) bool {
+	return operationHasSideEffects(n.Type(), config, "list")
+}
+
+func (n *SFTPNode) HasSideEffects(config map[string]interface{}) bool {
+	return operationHasSideEffects(n.Type(), config, "list")
+}
+
+func (n *GitHubNode) HasSideEffects(config map[string]interface{}) bool {
+	return operationHasSideEffects(n.Type(), config, "getRepo")
+}
+
+func (n *GoogleDriveNode) HasSideEffects(config map[string]interface{}) bool {
+	return operationHasSideEffects(n.Type(), config, "list")
+}
+
+func (n *GoogleSheetsNode) HasSideEffects(config map[string]interface{}) bool {
+	return operationHasSideEffects(n.Type(), config, "read")
+}
+
+// HasSideEffects reports true for GraphQL mutations
+func (n *GraphQLNode) HasSideEffects(config map[string]interface{}) bool {
+	query := strings.TrimSpace(getString(config, "query", ""))
+	return strings.HasPrefix(query, "mutation")
+}
+
+func (n *JiraNode) HasSideEffects(config map[string]interface{}) bool {
+	return operationHasSideEffects(n.Type(), config, "getIssue")
+}
+
+func (n *MongoDBNode) HasSideEffects(config map[string]interface{}) bool {
+	return operationHasSideEffects(n.Type(), config, "find")
+}
+
+func (n *MySQLNode) HasSideEffects(config map[string]interface{}) bool {
+	return sqlHasSideEffects(config)
+}
+
+func (n *NotionNode) HasSideEffects(config map[string]interface{}) bool {
+	return operationHasSideEffects(n.Type(), config, "getPage")
+}
+
+func (n *PostgresNode) HasSideEffects(config map[string]interface{}) bool {
+	return sqlHasSideEffects(config)
+}
+
+func (n *SalesforceNode) HasSideEffects(config map[string]interface{}) bool {
+	return operationHasSideEffects(n.Type(), config, "query")
+}
+
+func (n *SendGridNode) HasSideEffects(config map[string]interface{}) bool {
+	return operationHasSideEffects(n.Type(), config, "send")
+}
+
+func (n *SlackNode) HasSideEffects(config map[string]interface{}) bool {
+	return operationHasSideEffects(n.Type(), config, "sendMessage")
+}
+
+func (n *StripeNode) HasSideEffects(config map[string]interface{}) bool {
+	return operationHasSideEffects(n.Type(), config, "list")
+}
+
+func (n *TelegramNode) HasSideEffects(config map[string]interface{}) bool {
+	return operationHasSideEffects(n.Type(), config, "sendMessage")
+}
+
+func (n *TwilioNode) HasSideEffects(config map[string]interface{}) bool {
+	return operationHasSideEffects(n.Type(), config, "sendSms")
+}
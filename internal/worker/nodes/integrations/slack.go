@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/linkflow-ai/linkflow/internal/worker/core"
@@ -25,17 +26,17 @@ func (n *SlackNode) Execute(ctx context.Context, execCtx *core.ExecutionContext)
 	// Get credential
 	credIDStr := getString(config, "credentialId", "")
 	if credIDStr == "" {
-		return nil, fmt.Errorf("credential is required")
+		return nil, core.NewCredentialError("credential is required", nil)
 	}
 
 	credID, err := uuid.Parse(credIDStr)
 	if err != nil {
-		return nil, fmt.Errorf("invalid credential ID")
+		return nil, core.NewCredentialError("invalid credential ID", nil)
 	}
 
 	cred, err := execCtx.GetCredential(credID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get credential: %w", err)
+		return nil, core.NewCredentialError("failed to get credential", err)
 	}
 
 	token := cred.Token
@@ -209,10 +210,38 @@ func (n *SlackNode) makeRequest(ctx context.Context, token, method, url string,
 
 	if ok, _ := result["ok"].(bool); !ok {
 		errMsg, _ := result["error"].(string)
-		return result, fmt.Errorf("Slack API error: %s", errMsg)
+		return result, slackError(resp, respBody, errMsg)
 	}
 
 	return result, nil
 }
 
+// slackError classifies the error code of a Slack response, which usually
+// comes with HTTP 200
+func slackError(resp *http.Response, body []byte, code string) *core.NodeError {
+	e := core.NewAPIError("Slack", resp, body, fmt.Sprintf("Slack API error: %s", code))
+	switch {
+	case code == "invalid_auth" || code == "not_authed" || code == "token_revoked" ||
+		code == "token_expired" || code == "account_inactive" || code == "missing_scope":
+		e.Code = core.ErrCodeCredential
+		e.Retryable = false
+		e.Hint = "Check that the Slack credential is valid and has the required scopes"
+	case code == "ratelimited":
+		e.Code = core.ErrCodeRateLimited
+		e.Retryable = true
+		e.Hint = "Slack is rate limiting requests; retry later or lower the request rate"
+	case code == "internal_error" || code == "fatal_error" || code == "service_unavailable" ||
+		code == "request_timeout":
+		// Slack reports its own failures with ok:false on HTTP 200
+		e.Code = core.ErrCodeUpstream
+		e.Retryable = true
+		e.Hint = "Slack returned a server error; it is usually temporary"
+	case strings.HasSuffix(code, "_not_found"):
+		e.Code = core.ErrCodeNotFound
+		e.Retryable = false
+		e.Hint = "Check the channel, user or message IDs in the node configuration"
+	}
+	return e
+}
+
 var _ core.Node = (*SlackNode)(nil)
@@ -28,17 +28,17 @@ func (n *StripeNode) Execute(ctx context.Context, execCtx *core.ExecutionContext
 	// Get credential
 	credIDStr := getString(config, "credentialId", "")
 	if credIDStr == "" {
-		return nil, fmt.Errorf("Stripe credential is required")
+		return nil, core.NewCredentialError("Stripe credential is required", nil)
 	}
 
 	credID, err := uuid.Parse(credIDStr)
 	if err != nil {
-		return nil, fmt.Errorf("invalid credential ID")
+		return nil, core.NewCredentialError("invalid credential ID", nil)
 	}
 
 	cred, err := execCtx.GetCredential(credID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get credential: %w", err)
+		return nil, core.NewCredentialError("failed to get credential", err)
 	}
 
 	apiKey := getStringFromMap(cred.Data, "secretKey", getStringFromMap(cred.Data, "api_key", ""))
@@ -417,7 +417,7 @@ func (n *StripeNode) makeRequest(ctx context.Context, method, endpoint string, d
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, core.NewRequestError("request failed", err)
 	}
 	defer resp.Body.Close()
 
@@ -431,7 +431,7 @@ func (n *StripeNode) makeRequest(ctx context.Context, method, endpoint string, d
 	// Check for errors
 	if errObj, ok := result["error"].(map[string]interface{}); ok {
 		msg := getString(errObj, "message", "Unknown error")
-		return nil, fmt.Errorf("Stripe error: %s", msg)
+		return nil, core.NewAPIError("Stripe", resp, body, fmt.Sprintf("Stripe error: %s", msg))
 	}
 
 	return result, nil
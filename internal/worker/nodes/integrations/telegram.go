@@ -25,12 +25,12 @@ func (n *TelegramNode) Type() string {
 func (n *TelegramNode) Execute(ctx context.Context, execCtx *core.ExecutionContext) (map[string]interface{}, error) {
 	credID := getString(execCtx.Config, "credentialId", "")
 	if credID == "" {
-		return nil, fmt.Errorf("credential ID is required")
+		return nil, core.NewCredentialError("credential ID is required", nil)
 	}
 
 	cred, err := execCtx.GetCredential(parseUUID(credID))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get credential: %w", err)
+		return nil, core.NewCredentialError("failed to get credential", err)
 	}
 
 	botToken := cred.Token
@@ -260,7 +260,7 @@ func (n *TelegramNode) makeRequest(ctx context.Context, token, method string, pa
 
 	if ok, exists := result["ok"].(bool); exists && !ok {
 		description := result["description"]
-		return nil, fmt.Errorf("telegram API error: %v", description)
+		return nil, core.NewAPIError("Telegram", resp, respBody, fmt.Sprintf("telegram API error: %v", description))
 	}
 
 	return map[string]interface{}{
@@ -315,13 +315,13 @@ func (n *TwilioNode) makeRequest(ctx context.Context, method, endpoint string, d
 	client := &http.Client{Timeout: 30 * time.Second}
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, core.NewRequestError("request failed", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, core.NewRequestError("failed to read response", err)
 	}
 
 	var result map[string]interface{}
@@ -335,7 +335,7 @@ func (n *TwilioNode) makeRequest(ctx context.Context, method, endpoint string, d
 		if errMsg, ok := result["message"].(string); ok {
 			msg = errMsg
 		}
-		return nil, fmt.Errorf("%s (status %d)", msg, resp.StatusCode)
+		return nil, core.NewAPIError("Twilio", resp, body, fmt.Sprintf("%s (status %d)", msg, resp.StatusCode))
 	}
 
 	return result, nil
@@ -0,0 +1,253 @@
+package logic
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/linkflow-ai/linkflow/internal/worker/core"
+)
+
+// Approval decisions, also the node's output handles
+const (
+	ApprovalApproved = "approved"
+	ApprovalRejected = "rejected"
+	ApprovalTimeout  = "timeout"
+)
+
+// ApprovalNode suspends the execution until somebody approves or rejects it
+// through the resume URLs it hands out, optionally by email or Slack. It
+// continues down the "approved", "rejected" or "timeout" handle.
+type ApprovalNode struct {
+	deps *core.Dependencies
+}
+
+func NewApprovalNode() *ApprovalNode {
+	return &ApprovalNode{}
+}
+
+func (n *ApprovalNode) Type() string {
+	return "logic.approval"
+}
+
+func (n *ApprovalNode) SetDependencies(deps *core.Dependencies) {
+	n.deps = deps
+}
+
+// HasSideEffects reports true when approvers are notified
+func (n *ApprovalNode) HasSideEffects(config map[string]interface{}) bool {
+	return core.GetString(config, "notify", "none") != "none"
+}
+
+// Execute generates the approve/reject URLs and suspends the execution for
+// "timeout" seconds (7 days by default). The approvers are notified ("notify":
+// "email" with "to", or "slack" with "credentialId" and "channel") once the
+// wait is stored.
+func (n *ApprovalNode) Execute(ctx context.Context, execCtx *core.ExecutionContext) (map[string]interface{}, error) {
+	config := execCtx.Config
+	title := core.GetString(config, "title", "Approval required")
+	message := core.GetString(config, "message", "")
+	timeout := time.Duration(core.GetInt(config, "timeout", 7*86400)) * time.Second
+	expiresAt := time.Now().Add(timeout)
+
+	token, err := resumeToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate resume token: %w", err)
+	}
+
+	baseURL := "http://localhost:8080"
+	if n.deps != nil && n.deps.BaseURL != "" {
+		baseURL = n.deps.BaseURL
+	}
+	resumeURL := fmt.Sprintf("%s/api/v1/resume/%s", strings.TrimRight(baseURL, "/"), token)
+	approveURL := resumeURL + "/approve"
+	rejectURL := resumeURL + "/reject"
+
+	var notify func(ctx context.Context) error
+	switch channel := core.GetString(config, "notify", "none"); channel {
+	case "email":
+		notify = func(ctx context.Context) error {
+			return n.notifyEmail(ctx, config, title, message, approveURL, rejectURL, expiresAt)
+		}
+	case "slack":
+		notify = func(ctx context.Context) error {
+			return n.notifySlack(ctx, execCtx, title, message, approveURL, rejectURL)
+		}
+	case "none":
+	default:
+		return nil, fmt.Errorf("unknown notify channel: %s", channel)
+	}
+
+	output := map[string]interface{}{
+		"title":      title,
+		"message":    message,
+		"approveUrl": approveURL,
+		"rejectUrl":  rejectURL,
+		"expiresAt":  expiresAt.UTC().Format(time.RFC3339),
+		"data":       execCtx.Input["$json"],
+	}
+
+	return nil, &core.Suspension{
+		ResumeType:  core.ResumeWebhook,
+		ResumeAt:    expiresAt,
+		ResumeToken: token,
+		Output:      output,
+		Notify:      notify,
+	}
+}
+
+// Resume records the decision, who made it and their comment. Resume calls
+// without a valid decision count as a rejection.
+func (n *ApprovalNode) Resume(output, data map[string]interface{}) map[string]interface{} {
+	decision := core.GetString(data, "decision", "")
+	if timedOut, _ := data["timedOut"].(bool); timedOut {
+		decision = ApprovalTimeout
+	} else if decision != ApprovalApproved {
+		decision = ApprovalRejected
+	}
+
+	output["decision"] = decision
+	output["approved"] = decision == ApprovalApproved
+	output["respondedBy"] = core.GetString(data, "respondedBy", "")
+	output["comment"] = core.GetString(data, "comment", "")
+	output["respondedAt"] = core.GetString(data, "respondedAt", time.Now().UTC().Format(time.RFC3339))
+	return output
+}
+
+// Routes sends the output down the handle named after the decision
+func (n *ApprovalNode) Routes(output map[string]interface{}) []string {
+	decision, _ := output["decision"].(string)
+	if decision == "" {
+		return nil
+	}
+	return []string{decision}
+}
+
+// notifyEmail sends every recipient its own links, so the response records
+// who answered
+func (n *ApprovalNode) notifyEmail(ctx context.Context, config map[string]interface{}, title, message, approveURL, rejectURL string, expiresAt time.Time) error {
+	if n.deps == nil || n.deps.EmailService == nil {
+		return fmt.Errorf("email service is not configured")
+	}
+
+	recipients := core.GetStringArray(config, "to")
+	if to, ok := config["to"].(string); ok {
+		recipients = nil
+		for _, addr := range strings.Split(to, ",") {
+			if addr = strings.TrimSpace(addr); addr != "" {
+				recipients = append(recipients, addr)
+			}
+		}
+	}
+	if len(recipients) == 0 {
+		return fmt.Errorf("at least one recipient is required")
+	}
+
+	for _, to := range recipients {
+		by := "?by=" + url.QueryEscape(to)
+		if err := n.deps.EmailService.SendApprovalRequest(ctx, to, title, message, approveURL+by, rejectURL+by, expiresAt); err != nil {
+			return fmt.Errorf("failed to send approval request to %s: %w", to, err)
+		}
+	}
+	return nil
+}
+
+// notifySlack posts the request with approve/reject buttons to a channel
+func (n *ApprovalNode) notifySlack(ctx context.Context, execCtx *core.ExecutionContext, title, message, approveURL, rejectURL string) error {
+	config := execCtx.Config
+	channel := core.GetString(config, "channel", "")
+	if channel == "" {
+		return fmt.Errorf("channel is required")
+	}
+
+	credID, err := uuid.Parse(core.GetString(config, "credentialId", ""))
+	if err != nil {
+		return fmt.Errorf("invalid credential ID")
+	}
+	cred, err := execCtx.GetCredential(credID)
+	if err != nil {
+		return fmt.Errorf("failed to get credential: %w", err)
+	}
+	token := cred.Token
+	if token == "" {
+		token = cred.AccessToken
+	}
+
+	text := fmt.Sprintf("*%s*", title)
+	if message != "" {
+		text += "\n" + message
+	}
+	payload := map[string]interface{}{
+		"channel": channel,
+		"text":    text,
+		"blocks": []interface{}{
+			map[string]interface{}{
+				"type": "section",
+				"text": map[string]interface{}{"type": "mrkdwn", "text": text},
+			},
+			map[string]interface{}{
+				"type": "actions",
+				"elements": []interface{}{
+					map[string]interface{}{
+						"type":  "button",
+						"text":  map[string]interface{}{"type": "plain_text", "text": "Approve"},
+						"style": "primary",
+						"url":   approveURL,
+					},
+					map[string]interface{}{
+						"type":  "button",
+						"text":  map[string]interface{}{"type": "plain_text", "text": "Reject"},
+						"style": "danger",
+						"url":   rejectURL,
+					},
+				},
+			},
+		},
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://slack.com/api/chat.postMessage", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post approval request to Slack: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to read Slack response: %w", err)
+	}
+	if !result.OK {
+		return fmt.Errorf("slack API error: %s", result.Error)
+	}
+	return nil
+}
+
+// resumeToken generates the token of the execution's resume URLs
+func resumeToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -75,6 +75,15 @@ func (n *ConditionNode) Execute(ctx context.Context, execCtx *core.ExecutionCont
 	}, nil
 }
 
+// Routes implements core.Router, selecting the "true" or "false" handle
+func (n *ConditionNode) Routes(output map[string]interface{}) []string {
+	branch, _ := output["branch"].(string)
+	if branch == "" {
+		return []string{"false"}
+	}
+	return []string{branch}
+}
+
 func (n *ConditionNode) evaluateCondition(cond map[string]interface{}, input map[string]interface{}) bool {
 	leftValue := n.resolveValue(cond["leftValue"], input)
 	rightValue := n.resolveValue(cond["rightValue"], input)
@@ -44,6 +44,22 @@ func init() {
 		Version:     "1.0.0",
 	})
 
+	core.Register(&ErrorTriggerNode{}, core.NodeMeta{
+		Name:        "Error Trigger",
+		Description: "Start an error workflow when another workflow fails",
+		Category:    "triggers",
+		Icon:        "alert-triangle",
+		Version:     "1.0.0",
+	})
+
+	core.Register(&ApprovalNode{}, core.NodeMeta{
+		Name:        "Approval",
+		Description: "Wait for a person to approve or reject",
+		Category:    "logic",
+		Icon:        "user-check",
+		Version:     "1.0.0",
+	})
+
 	// Error handling nodes
 	core.Register(&TryCatchNode{}, core.NodeMeta{
 		Name:        "Try/Catch",
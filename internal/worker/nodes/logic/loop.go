@@ -34,6 +34,45 @@ func (n *LoopNode) Execute(ctx context.Context, execCtx *core.ExecutionContext)
 	}
 }
 
+// NextIteration implements core.Looper. forEach and times iterate over the
+// items computed by Execute; while re-checks its condition before every
+// iteration. The limit guard applies to every mode.
+func (n *LoopNode) NextIteration(config, output map[string]interface{}, index int) (map[string]interface{}, bool) {
+	limit := core.GetInt(config, "limit", 1000)
+	if index >= limit {
+		return nil, false
+	}
+
+	mode, _ := config["mode"].(string)
+	if mode == "while" {
+		if condition, ok := config["condition"]; ok {
+			if !core.ToBool(condition) {
+				return nil, false
+			}
+		} else if index > 0 && !core.GetBool(config, "continue", false) {
+			return nil, false
+		}
+		return map[string]interface{}{
+			"index": index,
+			"first": index == 0,
+		}, true
+	}
+
+	switch items := output["items"].(type) {
+	case []map[string]interface{}:
+		if index < len(items) {
+			return items[index], true
+		}
+	case []interface{}:
+		// Outputs restored from the result cache are JSON-decoded
+		if index < len(items) {
+			item, _ := items[index].(map[string]interface{})
+			return item, true
+		}
+	}
+	return nil, false
+}
+
 func (n *LoopNode) executeForEach(ctx context.Context, execCtx *core.ExecutionContext) (map[string]interface{}, error) {
 	var items []interface{}
 
@@ -49,7 +88,8 @@ func (n *LoopNode) executeForEach(ctx context.Context, execCtx *core.ExecutionCo
 	}
 
 	limit := core.GetInt(execCtx.Config, "limit", 1000)
-	if len(items) > limit {
+	truncated := len(items) > limit
+	if truncated {
 		items = items[:limit]
 	}
 
@@ -76,14 +116,15 @@ func (n *LoopNode) executeForEach(ctx context.Context, execCtx *core.ExecutionCo
 	return map[string]interface{}{
 		"items":       results,
 		"count":       len(results),
-		"interrupted": false,
+		"interrupted": truncated,
 	}, nil
 }
 
 func (n *LoopNode) executeTimes(ctx context.Context, execCtx *core.ExecutionContext) (map[string]interface{}, error) {
 	times := core.GetInt(execCtx.Config, "times", 1)
 	limit := core.GetInt(execCtx.Config, "limit", 1000)
-	if times > limit {
+	truncated := times > limit
+	if truncated {
 		times = limit
 	}
 
@@ -109,7 +150,7 @@ func (n *LoopNode) executeTimes(ctx context.Context, execCtx *core.ExecutionCont
 	return map[string]interface{}{
 		"items":       results,
 		"count":       len(results),
-		"interrupted": false,
+		"interrupted": truncated,
 	}, nil
 }
 
@@ -143,7 +184,7 @@ func (n *LoopNode) executeWhile(ctx context.Context, execCtx *core.ExecutionCont
 	return map[string]interface{}{
 		"items":       results,
 		"count":       len(results),
-		"interrupted": iteration >= limit,
+		"interrupted": iteration >= limit && core.GetBool(execCtx.Config, "continue", false),
 	}, nil
 }
 
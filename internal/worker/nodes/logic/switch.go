@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/linkflow-ai/linkflow/internal/worker/core"
@@ -48,6 +49,20 @@ func (n *SwitchNode) Execute(ctx context.Context, execCtx *core.ExecutionContext
 	}, nil
 }
 
+// Routes implements core.Router. A case can be wired either by its name or by
+// its index, so both are returned for a match.
+func (n *SwitchNode) Routes(output map[string]interface{}) []string {
+	matchedCase, _ := output["case"].(string)
+	if matchedCase == "" {
+		matchedCase = "default"
+	}
+	routes := []string{matchedCase}
+	if idx := core.GetInt(output, "caseIndex", -1); idx >= 0 {
+		routes = append(routes, strconv.Itoa(idx), fmt.Sprintf("output_%d", idx))
+	}
+	return routes
+}
+
 func (n *SwitchNode) evaluateExpressionMode(execCtx *core.ExecutionContext) (string, int) {
 	value := n.resolveValue(execCtx.Config["value"], execCtx.Input)
 	cases, _ := execCtx.Config["cases"].([]interface{})
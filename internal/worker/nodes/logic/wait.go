@@ -2,6 +2,7 @@ package logic
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/linkflow-ai/linkflow/internal/worker/core"
@@ -17,14 +18,71 @@ func (n *WaitNode) Type() string {
 	return "logic.wait"
 }
 
+// inlineWaitLimit is the longest wait served by sleeping in the worker.
+// Longer waits suspend the execution so the worker is released.
+const inlineWaitLimit = time.Minute
+
+// Execute waits for a time interval ("resume": "interval", the default), until
+// a timestamp ("until", with "dateTime" in RFC 3339) or for a call to the
+// execution's resume URL ("webhook", with an optional "timeout" in seconds).
+// Short intervals sleep in place; everything else suspends the execution.
 func (n *WaitNode) Execute(ctx context.Context, execCtx *core.ExecutionContext) (map[string]interface{}, error) {
-	unit, _ := execCtx.Config["unit"].(string)
+	output := map[string]interface{}{
+		"waited":      true,
+		"interrupted": false,
+		"data":        execCtx.Input["$json"],
+	}
+
+	resume, _ := execCtx.Config["resume"].(string)
+	switch resume {
+	case "until":
+		dateTime, _ := execCtx.Config["dateTime"].(string)
+		resumeAt, err := time.Parse(time.RFC3339, dateTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dateTime %q: %w", dateTime, err)
+		}
+		if time.Until(resumeAt) <= 0 {
+			output["waitedMs"] = 0
+			return output, nil
+		}
+		return nil, &core.Suspension{ResumeType: core.ResumeTimer, ResumeAt: resumeAt, Output: output}
+
+	case "webhook":
+		timeout := time.Duration(core.GetInt(execCtx.Config, "timeout", 86400)) * time.Second
+		return nil, &core.Suspension{ResumeType: core.ResumeWebhook, ResumeAt: time.Now().Add(timeout), Output: output}
+	}
+
+	duration := waitDuration(execCtx.Config)
+	output["waitedMs"] = duration.Milliseconds()
+
+	if duration > inlineWaitLimit {
+		return nil, &core.Suspension{ResumeType: core.ResumeTimer, ResumeAt: time.Now().Add(duration), Output: output}
+	}
+
+	startTime := time.Now()
+
+	select {
+	case <-ctx.Done():
+		return map[string]interface{}{
+			"waited":      false,
+			"interrupted": true,
+			"waitedMs":    time.Since(startTime).Milliseconds(),
+			"reason":      "cancelled",
+		}, ctx.Err()
+	case <-time.After(duration):
+		return output, nil
+	}
+}
+
+// waitDuration returns the configured wait interval, capped by "maxWait"
+// seconds when set
+func waitDuration(config map[string]interface{}) time.Duration {
+	unit, _ := config["unit"].(string)
 	if unit == "" {
 		unit = "seconds"
 	}
 
-	amount := core.GetInt(execCtx.Config, "amount", 1)
-	maxWait := core.GetInt(execCtx.Config, "maxWait", 3600)
+	amount := core.GetInt(config, "amount", 1)
 
 	var duration time.Duration
 	switch unit {
@@ -36,32 +94,16 @@ func (n *WaitNode) Execute(ctx context.Context, execCtx *core.ExecutionContext)
 		duration = time.Duration(amount) * time.Minute
 	case "hours":
 		duration = time.Duration(amount) * time.Hour
+	case "days":
+		duration = time.Duration(amount) * 24 * time.Hour
 	default:
 		duration = time.Duration(amount) * time.Second
 	}
 
-	if duration > time.Duration(maxWait)*time.Second {
+	if maxWait := core.GetInt(config, "maxWait", 0); maxWait > 0 && duration > time.Duration(maxWait)*time.Second {
 		duration = time.Duration(maxWait) * time.Second
 	}
-
-	startTime := time.Now()
-
-	select {
-	case <-ctx.Done():
-		return map[string]interface{}{
-			"waited":      false,
-			"interrupted": true,
-			"waitedMs":    time.Since(startTime).Milliseconds(),
-			"reason":      "cancelled",
-		}, ctx.Err()
-	case <-time.After(duration):
-		return map[string]interface{}{
-			"waited":      true,
-			"interrupted": false,
-			"waitedMs":    duration.Milliseconds(),
-			"data":        execCtx.Input["$json"],
-		}, nil
-	}
+	return duration
 }
 
 type ErrorTriggerNode struct{}
@@ -76,6 +118,12 @@ func (n *ErrorTriggerNode) Type() string {
 
 func (n *ErrorTriggerNode) Execute(ctx context.Context, execCtx *core.ExecutionContext) (map[string]interface{}, error) {
 	errorData := execCtx.Input["$error"]
+	if errorData == nil {
+		// Error workflows get the failure as their execution input
+		if input, ok := execCtx.Input["$input"].(map[string]interface{}); ok {
+			errorData = input["$error"]
+		}
+	}
 	if errorData == nil {
 		errorData = map[string]interface{}{
 			"message": "No error data available",
@@ -12,16 +12,27 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+// ActionPause is the action of a request pausing an execution instead of
+// cancelling it
+const ActionPause = "pause"
+
+// pauseRequestTTL bounds how long a pause request waits for the worker to
+// pick the execution up
+const pauseRequestTTL = 24 * time.Hour
+
 // CancellationManager manages workflow execution cancellation
 type CancellationManager struct {
 	redis   *redis.Client
 	active  sync.Map // executionID -> cancelFunc
+	pausers sync.Map // executionID -> pause func
 	channel string
 }
 
 // CancellationMessage represents a cancellation request
 type CancellationMessage struct {
 	ExecutionID uuid.UUID `json:"execution_id"`
+	// Action is ActionPause for pause requests and empty for cancellations
+	Action      string    `json:"action,omitempty"`
 	Reason      string    `json:"reason"`
 	RequestedBy string    `json:"requested_by"`
 	RequestedAt time.Time `json:"requested_at"`
@@ -40,9 +51,43 @@ func (cm *CancellationManager) Register(executionID uuid.UUID, cancel context.Ca
 	cm.active.Store(executionID.String(), cancel)
 }
 
-// Unregister removes an execution from cancellation tracking
+// RegisterPause registers the function pausing an execution. Unregister
+// removes it. A pause requested before the execution was registered pauses
+// it right away.
+func (cm *CancellationManager) RegisterPause(ctx context.Context, executionID uuid.UUID, pause func()) {
+	cm.pausers.Store(executionID.String(), pause)
+
+	requested, err := cm.redis.Exists(ctx, pauseKey(executionID)).Result()
+	if err != nil {
+		log.Warn().Err(err).Str("execution_id", executionID.String()).Msg("Failed to check pause request")
+		return
+	}
+	if requested > 0 {
+		pause()
+		log.Info().
+			Str("execution_id", executionID.String()).
+			Msg("Execution pause requested before it started")
+	}
+}
+
+// Unregister removes an execution from cancellation tracking and clears its
+// pause request
 func (cm *CancellationManager) Unregister(executionID uuid.UUID) {
 	cm.active.Delete(executionID.String())
+	if _, ok := cm.pausers.LoadAndDelete(executionID.String()); ok {
+		if err := cm.ClearPause(context.Background(), executionID); err != nil {
+			log.Warn().Err(err).Str("execution_id", executionID.String()).Msg("Failed to clear pause request")
+		}
+	}
+}
+
+// ClearPause removes the pause request of an execution, so it isn't paused
+// again when it's resumed
+func (cm *CancellationManager) ClearPause(ctx context.Context, executionID uuid.UUID) error {
+	if err := cm.redis.Del(ctx, pauseKey(executionID)).Err(); err != nil {
+		return fmt.Errorf("failed to clear pause request: %w", err)
+	}
+	return nil
 }
 
 // Cancel cancels an execution
@@ -85,6 +130,60 @@ func (cm *CancellationManager) Cancel(ctx context.Context, executionID uuid.UUID
 	return nil
 }
 
+// Pause asks the worker running an execution to pause it once its running
+// nodes finish. The request is stored until the execution ends, so a worker
+// that registers the execution after it was sent still pauses it.
+func (cm *CancellationManager) Pause(ctx context.Context, executionID uuid.UUID, requestedBy string) error {
+	if err := cm.redis.Set(ctx, pauseKey(executionID), requestedBy, pauseRequestTTL).Err(); err != nil {
+		return fmt.Errorf("failed to store pause request: %w", err)
+	}
+
+	// Try local pause first
+	if cm.pauseLocal(executionID) {
+		log.Info().
+			Str("execution_id", executionID.String()).
+			Msg("Execution pause requested locally")
+		return nil
+	}
+
+	// Publish to Redis for the worker running it
+	msg := CancellationMessage{
+		ExecutionID: executionID,
+		Action:      ActionPause,
+		RequestedBy: requestedBy,
+		RequestedAt: time.Now(),
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal pause message: %w", err)
+	}
+
+	if err := cm.redis.Publish(ctx, cm.channel, data).Err(); err != nil {
+		return fmt.Errorf("failed to publish pause: %w", err)
+	}
+
+	log.Info().
+		Str("execution_id", executionID.String()).
+		Msg("Pause request published")
+
+	return nil
+}
+
+func pauseKey(executionID uuid.UUID) string {
+	return fmt.Sprintf("execution:pause:%s", executionID)
+}
+
+func (cm *CancellationManager) pauseLocal(executionID uuid.UUID) bool {
+	if pause, ok := cm.pausers.Load(executionID.String()); ok {
+		if pauseFunc, ok := pause.(func()); ok {
+			pauseFunc()
+			return true
+		}
+	}
+	return false
+}
+
 // Listen starts listening for cancellation requests
 func (cm *CancellationManager) Listen(ctx context.Context) {
 	pubsub := cm.redis.Subscribe(ctx, cm.channel)
@@ -109,6 +208,10 @@ func (cm *CancellationManager) Listen(ctx context.Context) {
 				continue
 			}
 
+			if cancellation.Action == ActionPause {
+				cm.handlePause(cancellation)
+				continue
+			}
 			cm.handleCancellation(cancellation)
 		}
 	}
@@ -127,6 +230,15 @@ func (cm *CancellationManager) handleCancellation(msg CancellationMessage) {
 	}
 }
 
+func (cm *CancellationManager) handlePause(msg CancellationMessage) {
+	if cm.pauseLocal(msg.ExecutionID) {
+		log.Info().
+			Str("execution_id", msg.ExecutionID.String()).
+			Str("requested_by", msg.RequestedBy).
+			Msg("Execution pause requested via pubsub")
+	}
+}
+
 // IsActive checks if an execution is currently active
 func (cm *CancellationManager) IsActive(executionID uuid.UUID) bool {
 	_, ok := cm.active.Load(executionID.String())
@@ -0,0 +1,111 @@
+package processor
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/linkflow-ai/linkflow/internal/worker/core"
+	"github.com/rs/zerolog/log"
+)
+
+// DefaultCheckpointInterval is the minimum time between two checkpoints of
+// an execution, unless a node with side effects finished
+const DefaultCheckpointInterval = 5 * time.Second
+
+// Checkpointer stores the progress of running executions, so an execution
+// whose worker died continues on another worker without running the nodes
+// that already finished again. The state holds the raw node outputs, secrets
+// included, so implementations should store it encrypted.
+type Checkpointer interface {
+	Save(ctx context.Context, executionID uuid.UUID, state *ExecutionState) error
+	// Load returns nil without an error when the execution has no checkpoint
+	Load(ctx context.Context, executionID uuid.UUID) (*ExecutionState, error)
+	Delete(ctx context.Context, executionID uuid.UUID) error
+}
+
+// checkpoint marks a node finished by the scheduler, along with the loop body
+// it drove, and saves the execution's progress. Nodes with side effects are
+// saved right away so a recovered execution doesn't repeat them; otherwise
+// checkpoints are at least checkpointInterval apart.
+func (p *Processor) checkpoint(ctx context.Context, rctx *RuntimeContext, dag *DAG, node *NodeDefinition) {
+	rctx.checkpointMu.Lock()
+	defer rctx.checkpointMu.Unlock()
+
+	// Finished nodes are tracked without a checkpointer too; a paused
+	// execution keeps them
+	sideEffects := hasSideEffects(node)
+	rctx.finished[node.ID] = true
+	if body := loopBody(dag, node.ID); body != nil {
+		for nodeID, bodyNode := range body.Nodes {
+			rctx.finished[nodeID] = true
+			sideEffects = sideEffects || hasSideEffects(bodyNode)
+		}
+	}
+
+	if p.checkpointer == nil {
+		return
+	}
+
+	if !sideEffects && time.Since(rctx.lastCheckpoint) < p.checkpointInterval {
+		return
+	}
+
+	if err := p.checkpointer.Save(ctx, rctx.ExecutionID, rctx.checkpointState()); err != nil {
+		log.Warn().Err(err).
+			Str("execution_id", rctx.ExecutionID.String()).
+			Msg("Failed to save execution checkpoint")
+		return
+	}
+	rctx.lastCheckpoint = time.Now()
+}
+
+// recoverCheckpoint restores the progress saved before the execution was
+// interrupted
+func (p *Processor) recoverCheckpoint(ctx context.Context, rctx *RuntimeContext) {
+	if p.checkpointer == nil {
+		return
+	}
+
+	state, err := p.checkpointer.Load(ctx, rctx.ExecutionID)
+	if err != nil {
+		log.Warn().Err(err).
+			Str("execution_id", rctx.ExecutionID.String()).
+			Msg("Failed to load execution checkpoint, running from the start")
+		return
+	}
+	if state == nil {
+		return
+	}
+
+	restoreProgress(rctx, state)
+	log.Info().
+		Str("execution_id", rctx.ExecutionID.String()).
+		Int("nodes_restored", len(state.NodeResults)).
+		Msg("Execution recovered from checkpoint")
+}
+
+// checkpointState captures the nodes the scheduler finished. Loop nodes that
+// are still iterating and nodes that are running are left out, so they run
+// again after a recovery.
+func (rctx *RuntimeContext) checkpointState() *ExecutionState {
+	state := rctx.snapshot()
+	for nodeID := range state.NodeOutputs {
+		if !rctx.finished[nodeID] {
+			delete(state.NodeOutputs, nodeID)
+		}
+	}
+	for nodeID := range state.NodeResults {
+		if !rctx.finished[nodeID] {
+			delete(state.NodeResults, nodeID)
+			delete(state.NodeRoutes, nodeID)
+			delete(state.ItemRoutes, nodeID)
+		}
+	}
+	return state
+}
+
+func hasSideEffects(node *NodeDefinition) bool {
+	handler := core.Get(node.Type)
+	return handler != nil && core.HasSideEffects(handler, node.Config)
+}
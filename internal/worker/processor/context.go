@@ -13,7 +13,9 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/linkflow-ai/linkflow/internal/domain/models"
+	"github.com/linkflow-ai/linkflow/internal/worker/core"
 	"github.com/linkflow-ai/linkflow/internal/worker/events"
+	"github.com/linkflow-ai/linkflow/internal/worker/expression"
 )
 
 // RuntimeContext holds all state for a workflow execution
@@ -32,21 +34,27 @@ type RuntimeContext struct {
 	ctx       context.Context
 	cancel    context.CancelFunc
 	cancelled atomic.Bool
+	paused    atomic.Bool
 	progress  atomic.Int32 // 0-100
 
 	// Dependencies
 	GetCredential func(uuid.UUID) (*models.CredentialData, error)
 	publisher     *events.Publisher
 
-	// Expression evaluator
-	expression *ExpressionEvaluator
+	// Expression engine
+	expression *expression.Engine
 
 	// Execution tracking
-	startedAt       time.Time
-	totalNodes      int
-	completedNodes  atomic.Int32
-	currentNode     atomic.Value // *NodeDefinition
-	nodeStartTimes  sync.Map     // nodeID -> time.Time
+	startedAt      time.Time
+	totalNodes     int
+	completedNodes atomic.Int32
+	currentNode    atomic.Value    // *NodeDefinition
+	nodeStartTimes sync.Map        // nodeID -> time.Time
+	nodeResults    sync.Map        // nodeID -> *NodeResult
+	nodeRoutes     sync.Map        // nodeID -> map[string]bool of selected output handles
+	loopScopes     sync.Map        // nodeID -> $loop context of the enclosing loop iteration
+	itemRoutes     sync.Map        // nodeID -> [][]string of handles selected per output item
+	scope          map[string]bool // nodes executed by a partial run, nil for all
 
 	// Limits
 	MemoryLimit int64
@@ -60,6 +68,34 @@ type RuntimeContext struct {
 	lastError     error
 	lastErrorNode string
 	mu            sync.RWMutex
+
+	// Suspension requested by a waiting node
+	suspension    *core.Suspension
+	suspendedNode string
+
+	// Checkpointing: nodes the scheduler finished and when they were saved
+	checkpointMu   sync.Mutex
+	finished       map[string]bool
+	lastCheckpoint time.Time
+
+	// restored holds the nodes a resumed or recovered execution restored
+	// from its saved state; they were recorded by the run that finished them
+	restored map[string]bool
+
+	// Workflow metadata for expressions
+	workflowName string
+	nodes        map[string]*NodeDefinition
+	nodeRuns     map[string]int
+
+	// discardNodeData records nodes without their input and output, for
+	// workflows that don't save execution data
+	discardNodeData bool
+
+	// Workspace environment variables
+	env map[string]string
+
+	// Secrets resolved by the execution, scrubbed from what leaves it
+	redactor *redactor
 }
 
 // NewRuntimeContext creates a new runtime context
@@ -73,20 +109,25 @@ func NewRuntimeContext(
 	ctx, cancel := context.WithCancel(ctx)
 
 	rctx := &RuntimeContext{
-		ExecutionID:   executionID,
-		WorkflowID:    workflowID,
-		WorkspaceID:   workspaceID,
-		Input:         input,
-		Variables:     make(map[string]interface{}),
-		ctx:           ctx,
-		cancel:        cancel,
-		GetCredential: getCredential,
-		publisher:     publisher,
-		expression:    NewExpressionEvaluator(),
-		startedAt:     time.Now(),
-		TraceID:       uuid.New().String(),
-		SpanID:        uuid.New().String()[:8],
+		ExecutionID: executionID,
+		WorkflowID:  workflowID,
+		WorkspaceID: workspaceID,
+		Input:       input,
+		Variables:   make(map[string]interface{}),
+		ctx:         ctx,
+		cancel:      cancel,
+		publisher:   publisher,
+		expression:  expression.Shared(),
+		startedAt:   time.Now(),
+		TraceID:     uuid.New().String(),
+		SpanID:      uuid.New().String()[:8],
+		finished:    make(map[string]bool),
+		restored:    make(map[string]bool),
+		nodes:       make(map[string]*NodeDefinition),
+		nodeRuns:    make(map[string]int),
+		redactor:    &redactor{},
 	}
+	rctx.GetCredential = rctx.trackCredentials(getCredential)
 
 	return rctx
 }
@@ -124,6 +165,10 @@ func (rctx *RuntimeContext) UpdateProgress() {
 	}
 	completed := int(rctx.completedNodes.Load())
 	progress := (completed * 100) / rctx.totalNodes
+	if progress > 100 {
+		// Loop bodies complete their nodes once per iteration
+		progress = 100
+	}
 	rctx.progress.Store(int32(progress))
 }
 
@@ -134,6 +179,20 @@ func (rctx *RuntimeContext) SetNodeOutput(nodeID string, output interface{}) {
 	rctx.UpdateProgress()
 }
 
+// replaceNodeOutput overwrites a node's stored output without counting it as
+// another completed node
+func (rctx *RuntimeContext) replaceNodeOutput(nodeID string, output interface{}) {
+	rctx.NodeOutputs.Store(nodeID, output)
+}
+
+// resetNode clears the state of a node so it can execute again
+func (rctx *RuntimeContext) resetNode(nodeID string) {
+	rctx.NodeOutputs.Delete(nodeID)
+	rctx.nodeResults.Delete(nodeID)
+	rctx.nodeRoutes.Delete(nodeID)
+	rctx.itemRoutes.Delete(nodeID)
+}
+
 // GetNodeOutput retrieves output for a node (thread-safe)
 func (rctx *RuntimeContext) GetNodeOutput(nodeID string) (interface{}, bool) {
 	return rctx.NodeOutputs.Load(nodeID)
@@ -149,6 +208,30 @@ func (rctx *RuntimeContext) GetAllNodeOutputs() map[string]interface{} {
 	return outputs
 }
 
+// SetNodeResult records the execution result of a node (thread-safe)
+func (rctx *RuntimeContext) SetNodeResult(result *NodeResult) {
+	rctx.nodeResults.Store(result.NodeID, result)
+}
+
+// GetNodeResult retrieves the execution result of a node (thread-safe)
+func (rctx *RuntimeContext) GetNodeResult(nodeID string) (*NodeResult, bool) {
+	v, ok := rctx.nodeResults.Load(nodeID)
+	if !ok {
+		return nil, false
+	}
+	return v.(*NodeResult), true
+}
+
+// GetAllNodeResults returns all recorded node results as a map
+func (rctx *RuntimeContext) GetAllNodeResults() map[string]*NodeResult {
+	results := make(map[string]*NodeResult)
+	rctx.nodeResults.Range(func(key, value interface{}) bool {
+		results[key.(string)] = value.(*NodeResult)
+		return true
+	})
+	return results
+}
+
 // SetVariable sets a workflow variable
 func (rctx *RuntimeContext) SetVariable(key string, value interface{}) {
 	rctx.mu.Lock()
@@ -201,10 +284,15 @@ func (rctx *RuntimeContext) Duration() time.Duration {
 
 // ResolveExpression evaluates an expression in the current context
 func (rctx *RuntimeContext) ResolveExpression(expr string) (interface{}, error) {
-	exprCtx := &ExpressionContext{
+	return rctx.expression.Evaluate(expr, rctx.expressionContext(nil))
+}
+
+// expressionContext builds the expression variables, scoped to a node when given
+func (rctx *RuntimeContext) expressionContext(node *NodeDefinition) *expression.Context {
+	exprCtx := &expression.Context{
 		Input:       rctx.Input,
 		JSON:        rctx.Input["$json"],
-		Node:        rctx.GetAllNodeOutputs(),
+		Node:        rctx.nodeReferences(),
 		Vars:        rctx.Variables,
 		Env:         rctx.getEnvironmentVariables(),
 		Now:         time.Now(),
@@ -212,35 +300,157 @@ func (rctx *RuntimeContext) ResolveExpression(expr string) (interface{}, error)
 		Timestamp:   time.Now().Unix(),
 		ExecutionID: rctx.ExecutionID.String(),
 		WorkflowID:  rctx.WorkflowID.String(),
+		Trigger:     rctx.Input["$trigger"],
+		Workflow: map[string]interface{}{
+			"id":          rctx.WorkflowID.String(),
+			"name":        rctx.workflowName,
+			"workspaceId": rctx.WorkspaceID.String(),
+		},
+	}
+
+	if node != nil {
+		exprCtx.Loop = rctx.LoopScope(node.ID)
+		exprCtx.Prev = rctx.previousNode(node)
+		exprCtx.RunIndex = rctx.RunIndex(node.ID)
+	}
+
+	return exprCtx
+}
+
+// setWorkflow makes the workflow's name and nodes available to expressions
+func (rctx *RuntimeContext) setWorkflow(workflow *WorkflowDefinition) {
+	rctx.workflowName = workflow.Name
+	rctx.discardNodeData = !workflow.Settings.SaveExecutionData
+	for _, node := range workflow.Nodes {
+		rctx.nodes[node.ID] = node
+	}
+}
+
+// nodeReferences returns $node: outputs by node ID, and references by node
+// name. Node IDs win when a name is also the ID of another node.
+func (rctx *RuntimeContext) nodeReferences() map[string]interface{} {
+	outputs := rctx.GetAllNodeOutputs()
+	refs := make(map[string]interface{}, 2*len(outputs))
+	for nodeID, output := range outputs {
+		refs[nodeID] = output
+	}
+	for nodeID, output := range outputs {
+		node, ok := rctx.nodes[nodeID]
+		if !ok || node.Name == "" {
+			continue
+		}
+		if _, isID := rctx.nodes[node.Name]; isID {
+			continue
+		}
+		refs[node.Name] = rctx.nodeReference(node, output)
+	}
+	return refs
+}
+
+// previousNode returns $prev, the first active input of a node with output
+func (rctx *RuntimeContext) previousNode(node *NodeDefinition) map[string]interface{} {
+	for _, conn := range rctx.ActiveInputs(node) {
+		output, ok := rctx.GetNodeOutput(conn.SourceNodeID)
+		if !ok {
+			continue
+		}
+		if source, ok := rctx.nodes[conn.SourceNodeID]; ok {
+			return rctx.nodeReference(source, output)
+		}
+	}
+	return nil
+}
+
+func (rctx *RuntimeContext) nodeReference(node *NodeDefinition, output interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"json":     output,
+		"id":       node.ID,
+		"name":     node.Name,
+		"type":     node.Type,
+		"runIndex": rctx.RunIndex(node.ID),
 	}
+}
 
-	return rctx.expression.Evaluate(expr, exprCtx)
+// startRun counts a run of a node, exposed to expressions as $runIndex
+func (rctx *RuntimeContext) startRun(nodeID string) {
+	rctx.mu.Lock()
+	defer rctx.mu.Unlock()
+	rctx.nodeRuns[nodeID]++
+}
+
+// RunIndex returns how many times a node ran before its latest run
+func (rctx *RuntimeContext) RunIndex(nodeID string) int {
+	rctx.mu.RLock()
+	defer rctx.mu.RUnlock()
+	if runs := rctx.nodeRuns[nodeID]; runs > 0 {
+		return runs - 1
+	}
+	return 0
+}
+
+// SetLoopScope exposes a loop iteration as $loop to a node in the loop body
+func (rctx *RuntimeContext) SetLoopScope(nodeID string, scope map[string]interface{}) {
+	rctx.loopScopes.Store(nodeID, scope)
+}
+
+// LoopScope returns the $loop context of a node, or nil outside a loop body
+func (rctx *RuntimeContext) LoopScope(nodeID string) map[string]interface{} {
+	if v, ok := rctx.loopScopes.Load(nodeID); ok {
+		return v.(map[string]interface{})
+	}
+	return nil
 }
 
 // getEnvironmentVariables returns environment variables available to expressions
 func (rctx *RuntimeContext) getEnvironmentVariables() map[string]string {
-	env := make(map[string]string)
-	
+	env := make(map[string]string, len(rctx.env)+4)
+
+	// Add the workspace's variables. For security, system environment
+	// variables are NOT exposed directly
+	for name, value := range rctx.env {
+		env[name] = value
+	}
+
 	// Add execution-related env vars
 	env["EXECUTION_ID"] = rctx.ExecutionID.String()
 	env["WORKFLOW_ID"] = rctx.WorkflowID.String()
 	env["WORKSPACE_ID"] = rctx.WorkspaceID.String()
 	env["TRACE_ID"] = rctx.TraceID
-	
-	// Note: In production, additional environment variables can be injected
-	// via the processor's configuration or from the workflow settings
-	// e.g., API keys, base URLs, feature flags, etc.
-	// For security, system environment variables are NOT exposed directly
-	
+
 	return env
 }
 
 // ResolveConfig resolves all expressions in a config map
 func (rctx *RuntimeContext) ResolveConfig(config map[string]interface{}) (map[string]interface{}, error) {
+	return rctx.resolveConfig(config, rctx.expressionContext(nil))
+}
+
+// ResolveNodeConfig resolves all expressions in a node's config map with the
+// node's scoped variables (such as $loop) available. $item is the node's first
+// input item.
+func (rctx *RuntimeContext) ResolveNodeConfig(node *NodeDefinition, config map[string]interface{}) (map[string]interface{}, error) {
+	exprCtx := rctx.expressionContext(node)
+	if items := rctx.NodeItems(node); len(items) > 0 {
+		exprCtx.Item = items[0].JSON
+	}
+	return rctx.resolveConfig(config, exprCtx)
+}
+
+// ResolveItemConfig resolves a node's config for a single input item, with the
+// item exposed as $json, $item and $itemIndex
+func (rctx *RuntimeContext) ResolveItemConfig(node *NodeDefinition, config map[string]interface{}, item core.Item, index int) (map[string]interface{}, error) {
+	exprCtx := rctx.expressionContext(node)
+	exprCtx.JSON = item.JSON
+	exprCtx.Item = item.JSON
+	exprCtx.ItemIndex = index
+	return rctx.resolveConfig(config, exprCtx)
+}
+
+func (rctx *RuntimeContext) resolveConfig(config map[string]interface{}, exprCtx *expression.Context) (map[string]interface{}, error) {
 	resolved := make(map[string]interface{})
 
 	for key, value := range config {
-		resolvedValue, err := rctx.resolveValue(value)
+		resolvedValue, err := rctx.resolveValue(value, exprCtx)
 		if err != nil {
 			return nil, fmt.Errorf("failed to resolve %s: %w", key, err)
 		}
@@ -250,18 +460,18 @@ func (rctx *RuntimeContext) ResolveConfig(config map[string]interface{}) (map[st
 	return resolved, nil
 }
 
-func (rctx *RuntimeContext) resolveValue(value interface{}) (interface{}, error) {
+func (rctx *RuntimeContext) resolveValue(value interface{}, exprCtx *expression.Context) (interface{}, error) {
 	switch v := value.(type) {
 	case string:
 		if strings.Contains(v, "{{") {
-			return rctx.ResolveExpression(v)
+			return rctx.expression.Evaluate(v, exprCtx)
 		}
 		return v, nil
 
 	case map[string]interface{}:
 		resolved := make(map[string]interface{})
 		for k, val := range v {
-			resolvedVal, err := rctx.resolveValue(val)
+			resolvedVal, err := rctx.resolveValue(val, exprCtx)
 			if err != nil {
 				return nil, err
 			}
@@ -272,7 +482,7 @@ func (rctx *RuntimeContext) resolveValue(value interface{}) (interface{}, error)
 	case []interface{}:
 		resolved := make([]interface{}, len(v))
 		for i, val := range v {
-			resolvedVal, err := rctx.resolveValue(val)
+			resolvedVal, err := rctx.resolveValue(val, exprCtx)
 			if err != nil {
 				return nil, err
 			}
@@ -295,13 +505,14 @@ func (rctx *RuntimeContext) PrepareNodeInput(node *NodeDefinition) map[string]in
 		input["$json"] = json
 	}
 
-	// Add outputs from connected nodes
-	for _, conn := range node.Inputs {
+	// Add outputs from connected nodes on the branches that were taken
+	activeInputs := rctx.ActiveInputs(node)
+	for _, conn := range activeInputs {
 		if output, ok := rctx.GetNodeOutput(conn.SourceNodeID); ok {
 			input[conn.SourceNodeID] = output
 
 			// Also set as $json if single input
-			if len(node.Inputs) == 1 {
+			if len(activeInputs) == 1 {
 				if outMap, ok := output.(map[string]interface{}); ok {
 					input["$json"] = outMap
 				}
@@ -309,12 +520,20 @@ func (rctx *RuntimeContext) PrepareNodeInput(node *NodeDefinition) map[string]in
 		}
 	}
 
+	// Add the input items from all active connections
+	input["$items"] = core.ItemsToList(rctx.NodeItems(node))
+
 	// Add all node outputs for $node reference
 	input["$node"] = rctx.GetAllNodeOutputs()
 
 	// Add variables
 	input["$vars"] = rctx.Variables
 
+	// Add the enclosing loop iteration
+	if scope := rctx.LoopScope(node.ID); scope != nil {
+		input["$loop"] = scope
+	}
+
 	// Add execution metadata
 	input["$execution"] = map[string]interface{}{
 		"id":          rctx.ExecutionID.String(),
@@ -352,15 +571,38 @@ func (rctx *RuntimeContext) PublishNodeStarted(node *NodeDefinition) {
 func (rctx *RuntimeContext) PublishNodeCompleted(node *NodeDefinition, durationMs int, output interface{}) {
 	if rctx.publisher != nil {
 		// Truncate output for preview
-		preview := truncateOutput(output, 1000)
+		preview := truncateOutput(rctx.Redact(output), 1000)
 		_ = rctx.publisher.NodeCompleted(rctx.ctx, rctx.WorkspaceID, rctx.WorkflowID, rctx.ExecutionID, node.ID, durationMs, preview)
 	}
 }
 
-// PublishNodeFailed publishes node failed event
-func (rctx *RuntimeContext) PublishNodeFailed(node *NodeDefinition, errMsg string) {
+// PublishNodePinned publishes node completed event for pinned output
+func (rctx *RuntimeContext) PublishNodePinned(node *NodeDefinition, output interface{}) {
+	if rctx.publisher != nil {
+		preview := truncateOutput(rctx.Redact(output), 1000)
+		_ = rctx.publisher.NodePinned(rctx.ctx, rctx.WorkspaceID, rctx.WorkflowID, rctx.ExecutionID, node.ID, preview)
+	}
+}
+
+// PublishNodeSkipped publishes node skipped event
+func (rctx *RuntimeContext) PublishNodeSkipped(node *NodeDefinition) {
+	if rctx.publisher != nil {
+		_ = rctx.publisher.NodeSkipped(rctx.ctx, rctx.WorkspaceID, rctx.WorkflowID, rctx.ExecutionID, node.ID)
+	}
+}
+
+// PublishNodeRetrying publishes node retrying event
+func (rctx *RuntimeContext) PublishNodeRetrying(node *NodeDefinition, attempt int, delay time.Duration, errMsg string) {
+	if rctx.publisher != nil {
+		_ = rctx.publisher.NodeRetrying(rctx.ctx, rctx.WorkspaceID, rctx.WorkflowID, rctx.ExecutionID, node.ID, attempt, delay.Milliseconds(), rctx.RedactString(errMsg))
+	}
+}
+
+// PublishNodeFailed publishes node failed event with the error's details
+func (rctx *RuntimeContext) PublishNodeFailed(node *NodeDefinition, err error) {
 	if rctx.publisher != nil {
-		_ = rctx.publisher.NodeFailed(rctx.ctx, rctx.WorkspaceID, rctx.WorkflowID, rctx.ExecutionID, node.ID, errMsg)
+		details := rctx.redactNodeError(core.ToNodeError(err))
+		_ = rctx.publisher.NodeFailed(rctx.ctx, rctx.WorkspaceID, rctx.WorkflowID, rctx.ExecutionID, node.ID, rctx.RedactString(err.Error()), details.ToMap())
 	}
 }
 
@@ -409,3 +409,23 @@ func (d *DAG) SubDAG(startNodeID string) *DAG {
 }
 
 
+
+// Ancestors returns all nodes from which the given node is reachable
+func (d *DAG) Ancestors(nodeID string) map[string]bool {
+	ancestors := make(map[string]bool)
+	queue := []string{nodeID}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, predecessor := range d.GetPredecessors(current) {
+			if !ancestors[predecessor] {
+				ancestors[predecessor] = true
+				queue = append(queue, predecessor)
+			}
+		}
+	}
+
+	return ancestors
+}
@@ -0,0 +1,156 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/linkflow-ai/linkflow/internal/worker/core"
+)
+
+// NodeItems returns the input items of a node: the items of every active
+// input connection in order, or the workflow input for root nodes. Items a
+// router emitted on a different handle are filtered out.
+func (rctx *RuntimeContext) NodeItems(node *NodeDefinition) []core.Item {
+	if len(node.Inputs) == 0 {
+		return core.ItemsFromOutput(rctx.Input)
+	}
+
+	var items []core.Item
+	for _, conn := range rctx.ActiveInputs(node) {
+		output, ok := rctx.GetNodeOutput(conn.SourceNodeID)
+		if !ok {
+			continue
+		}
+		items = append(items, rctx.itemsForConnection(conn, core.ItemsFromOutput(output))...)
+	}
+	return items
+}
+
+// itemsForConnection keeps the items a per-item router sent down the
+// connection's handle
+func (rctx *RuntimeContext) itemsForConnection(conn ConnectionRef, items []core.Item) []core.Item {
+	v, ok := rctx.itemRoutes.Load(conn.SourceNodeID)
+	if !ok || defaultHandles[conn.SourceHandle] {
+		return items
+	}
+
+	routes := v.([][]string)
+	filtered := make([]core.Item, 0, len(items))
+	for i, item := range items {
+		if i >= len(routes) {
+			break
+		}
+		for _, handle := range routes[i] {
+			if handle == conn.SourceHandle {
+				filtered = append(filtered, item)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// executeForEachItem runs a node once per input item. The per-item outputs are
+// collected into a single {"items": [...], "count": n} output; for routers the
+// returned routes are the union of the handles selected by each item. The
+// returned retry count is the total across all items.
+func (p *Processor) executeForEachItem(
+	ctx context.Context,
+	rctx *RuntimeContext,
+	node *NodeDefinition,
+	handler core.Node,
+	input map[string]interface{},
+	items []core.Item,
+	opts ExecutionOptions,
+) (map[string]interface{}, []string, int, error) {
+	_, isRouter := handler.(core.Router)
+	totalRetries := 0
+
+	results := make([]interface{}, 0, len(items))
+	var itemRoutes [][]string
+	selected := make(map[string]bool)
+
+	for i, item := range items {
+		select {
+		case <-ctx.Done():
+			return nil, nil, totalRetries, ctx.Err()
+		default:
+		}
+
+		config, err := rctx.ResolveItemConfig(node, node.Config, item, i)
+		if err != nil {
+			return nil, nil, totalRetries, fmt.Errorf("item %d: failed to resolve config: %w", i, err)
+		}
+		applyOverrides(config, node, opts)
+
+		itemInput := core.CopyMap(input)
+		itemInput["$json"] = item.JSON
+		itemInput["$item"] = item.JSON
+		itemInput["$itemIndex"] = i
+
+		output, retries, err := p.invokeNode(ctx, rctx, node, handler, &core.ExecutionContext{
+			ExecutionID:   rctx.ExecutionID,
+			WorkflowID:    rctx.WorkflowID,
+			WorkspaceID:   rctx.WorkspaceID,
+			NodeID:        node.ID,
+			Input:         itemInput,
+			Items:         items,
+			ItemIndex:     i,
+			Config:        config,
+			Variables:     rctx.Variables,
+			GetCredential: rctx.GetCredential,
+		}, opts)
+		totalRetries += retries
+		if err != nil {
+			return nil, nil, totalRetries, fmt.Errorf("item %d: %w", i, err)
+		}
+
+		routes := resolveRoutes(handler, output)
+		for _, r := range routes {
+			selected[r] = true
+		}
+		for _, produced := range core.ItemsFromOutput(output) {
+			results = append(results, produced.JSON)
+			itemRoutes = append(itemRoutes, routes)
+		}
+	}
+
+	if !isRouter {
+		return map[string]interface{}{
+			"items": results,
+			"count": len(results),
+		}, nil, totalRetries, nil
+	}
+
+	rctx.itemRoutes.Store(node.ID, itemRoutes)
+	routes := make([]string, 0, len(selected))
+	for r := range selected {
+		routes = append(routes, r)
+	}
+
+	return map[string]interface{}{
+		"items": results,
+		"count": len(results),
+	}, routes, totalRetries, nil
+}
+
+// applyOverrides applies the execution's config overrides for a node
+func applyOverrides(config map[string]interface{}, node *NodeDefinition, opts ExecutionOptions) {
+	if overrides, ok := opts.NodeOverrides[node.ID]; ok {
+		for k, v := range overrides {
+			config[k] = v
+		}
+	}
+}
+
+// recordedInput is the input persisted with a node result: the resolved config
+// (absent for per-item nodes, whose config differs per item) and the input items
+func recordedInput(config map[string]interface{}, items []core.Item) map[string]interface{} {
+	input := map[string]interface{}{
+		"items": core.ItemsToList(items),
+	}
+	if config != nil {
+		input["config"] = config
+	}
+	return input
+}
@@ -0,0 +1,236 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/linkflow-ai/linkflow/internal/worker/core"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// LoopBodyHandle is the output handle of a loop node wired to its body
+	LoopBodyHandle = "loop"
+	// LoopDoneHandle is the output handle of a loop node that receives the
+	// aggregated iteration results
+	LoopDoneHandle = "done"
+)
+
+// runNode executes a node and, for loop nodes, drives their loop body
+func (p *Processor) runNode(ctx context.Context, rctx *RuntimeContext, dag *DAG, node *NodeDefinition, opts ExecutionOptions) error {
+	looper, isLoop := core.Get(node.Type).(core.Looper)
+
+	// Give the loop's own config a $loop to resolve against before the first iteration
+	if isLoop && rctx.LoopScope(node.ID) == nil {
+		rctx.SetLoopScope(node.ID, map[string]interface{}{
+			"index":   0,
+			"results": []interface{}{},
+		})
+	}
+
+	err := p.executeNode(ctx, rctx, node, opts)
+
+	// A node that did not produce output (e.g. skipped by options) or whose
+	// output is pinned, or that failed with a handled error, has no loop to run
+	if result, ok := rctx.GetNodeResult(node.ID); err == nil && isLoop && ok && !result.Pinned && result.Error == "" {
+		err = p.executeLoop(ctx, rctx, dag, node, looper, opts)
+	}
+
+	p.recordNode(rctx, node.ID)
+	return err
+}
+
+// stoppedAtLimit reports whether a loop that ended before iteration index
+// was cut off by its limit while items were left: items the node dropped
+// over the limit, or an iteration the limit alone prevented
+func stoppedAtLimit(looper core.Looper, config, output map[string]interface{}, index int) bool {
+	if index < core.GetInt(config, "limit", 1000) {
+		return false
+	}
+	if truncated, _ := output["interrupted"].(bool); truncated {
+		return true
+	}
+	raised := core.CopyMap(config)
+	raised["limit"] = index + 1
+	_, more := looper.NextIteration(raised, output, index)
+	return more
+}
+
+// executeLoop re-executes the body sub-graph of a loop node once per
+// iteration. During an iteration the loop node's output is the iteration
+// context, so body nodes see the current item as $json; afterwards it is
+// replaced by the aggregated results and only the "done" handle is followed.
+func (p *Processor) executeLoop(ctx context.Context, rctx *RuntimeContext, dag *DAG, node *NodeDefinition, looper core.Looper, opts ExecutionOptions) error {
+	body := loopBody(dag, node.ID)
+	if body == nil {
+		// Nothing wired to the loop handle: keep the node's output as is
+		return nil
+	}
+
+	order, err := body.TopologicalSort()
+	if err != nil {
+		return err
+	}
+
+	rawOutput, _ := rctx.GetNodeOutput(node.ID)
+	output, _ := rawOutput.(map[string]interface{})
+
+	results := make([]interface{}, 0)
+	interrupted := false
+
+	for index := 0; ; index++ {
+		if rctx.IsCancelled() {
+			return fmt.Errorf("execution cancelled")
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		// Re-resolve the loop config so a while condition can look at $loop
+		rctx.SetLoopScope(node.ID, map[string]interface{}{
+			"index":   index,
+			"results": results,
+		})
+		config, err := rctx.ResolveNodeConfig(node, node.Config)
+		if err != nil {
+			return fmt.Errorf("failed to resolve loop config: %w", err)
+		}
+
+		iteration, ok := looper.NextIteration(config, output, index)
+		if !ok {
+			interrupted = stoppedAtLimit(looper, config, output, index)
+			break
+		}
+
+		scope := core.CopyMap(iteration)
+		scope["index"] = index
+		scope["results"] = results
+
+		for _, bodyNodeID := range order {
+			rctx.resetNode(bodyNodeID)
+			rctx.SetLoopScope(bodyNodeID, scope)
+		}
+		rctx.replaceNodeOutput(node.ID, iteration)
+		rctx.SetNodeRoutes(node.ID, []string{LoopBodyHandle})
+
+		if err := p.executeBody(ctx, rctx, body, order, opts); err != nil {
+			return err
+		}
+
+		results = append(results, collectIterationResult(rctx, body))
+	}
+
+	log.Debug().
+		Str("execution_id", rctx.ExecutionID.String()).
+		Str("node_id", node.ID).
+		Int("iterations", len(results)).
+		Msg("Loop completed")
+
+	final := map[string]interface{}{
+		"results":     results,
+		"count":       len(results),
+		"interrupted": interrupted,
+	}
+	rctx.replaceNodeOutput(node.ID, final)
+	rctx.SetNodeRoutes(node.ID, []string{LoopDoneHandle})
+	if result, ok := rctx.GetNodeResult(node.ID); ok {
+		completed := *result
+		completed.Output = final
+		rctx.SetNodeResult(&completed)
+	}
+
+	return nil
+}
+
+// executeBody runs one iteration of a loop body in topological order
+func (p *Processor) executeBody(ctx context.Context, rctx *RuntimeContext, body *DAG, order []string, opts ExecutionOptions) error {
+	for _, nodeID := range order {
+		// Nodes of a nested loop body were already run by their own loop
+		if _, done := rctx.GetNodeResult(nodeID); done {
+			continue
+		}
+
+		node := body.GetNode(nodeID)
+		if node == nil {
+			continue
+		}
+
+		if !shouldExecute(rctx, node) {
+			p.markSkipped(rctx, node)
+			continue
+		}
+
+		if err := p.runNode(ctx, rctx, body, node, opts); err != nil {
+			rctx.SetError(err, nodeID)
+			return err
+		}
+	}
+	return nil
+}
+
+// loopBody returns the sub-graph reachable from the loop handle of a node, or
+// nil if nothing is wired to it
+func loopBody(dag *DAG, loopNodeID string) *DAG {
+	var starts []string
+	for _, nodeID := range dag.GetSuccessors(loopNodeID) {
+		node := dag.GetNode(nodeID)
+		if node == nil {
+			continue
+		}
+		for _, conn := range node.Inputs {
+			if conn.SourceNodeID == loopNodeID && conn.SourceHandle == LoopBodyHandle {
+				starts = append(starts, nodeID)
+				break
+			}
+		}
+	}
+	if len(starts) == 0 {
+		return nil
+	}
+
+	body := &DAG{
+		Nodes:    make(map[string]*NodeDefinition),
+		Edges:    make(map[string][]string),
+		InDegree: make(map[string]int),
+	}
+	for _, start := range starts {
+		sub := dag.SubDAG(start)
+		for nodeID, node := range sub.Nodes {
+			body.Nodes[nodeID] = node
+			body.Edges[nodeID] = sub.Edges[nodeID]
+		}
+	}
+
+	for nodeID := range body.Nodes {
+		body.InDegree[nodeID] = 0
+	}
+	for _, targets := range body.Edges {
+		for _, target := range targets {
+			if _, ok := body.Nodes[target]; ok {
+				body.InDegree[target]++
+			}
+		}
+	}
+	body.computeRootAndLeafNodes()
+
+	return body
+}
+
+// collectIterationResult gathers the outputs of the body's leaf nodes. A
+// single leaf contributes its output directly; several are keyed by node ID.
+func collectIterationResult(rctx *RuntimeContext, body *DAG) interface{} {
+	outputs := make(map[string]interface{})
+	for _, leafID := range body.LeafNodes {
+		if output, ok := rctx.GetNodeOutput(leafID); ok {
+			outputs[leafID] = output
+		}
+	}
+
+	if len(body.LeafNodes) == 1 {
+		return outputs[body.LeafNodes[0]]
+	}
+	return outputs
+}
@@ -0,0 +1,35 @@
+package processor
+
+import "github.com/linkflow-ai/linkflow/internal/worker/core"
+
+// Per-node error policies (NodeDefinition.OnError)
+const (
+	// OnErrorStop fails the execution (default)
+	OnErrorStop = "stop"
+	// OnErrorContinue completes the node with the error as its output
+	OnErrorContinue = "continue"
+	// OnErrorRoute sends the error down the node's ErrorHandle only
+	OnErrorRoute = "route"
+)
+
+// ErrorHandle is the output handle that carries the error of a node whose
+// OnError policy is OnErrorRoute. It carries nothing when the node succeeds.
+const ErrorHandle = "error"
+
+// handlesError reports whether a failed node keeps the execution going
+func (n *NodeDefinition) handlesError() bool {
+	return n.OnError == OnErrorContinue || n.OnError == OnErrorRoute
+}
+
+// errorOutput is the output of a node that failed under a continue or route
+// policy. The error carries the NodeError fields (code, httpStatus, hint...).
+func errorOutput(node *NodeDefinition, input map[string]interface{}, err error) map[string]interface{} {
+	details := core.ToNodeError(err).ToMap()
+	details["nodeId"] = node.ID
+	details["nodeName"] = node.Name
+	details["nodeType"] = node.Type
+	return map[string]interface{}{
+		"error": details,
+		"input": input["$json"],
+	}
+}
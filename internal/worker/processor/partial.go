@@ -0,0 +1,135 @@
+package processor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/linkflow-ai/linkflow/internal/worker/core"
+)
+
+// executionScope returns the nodes a partial run executes: the start node and
+// everything downstream of it, limited to the stop node and its upstream
+// nodes. A nil scope means the whole workflow runs.
+func executionScope(dag *DAG, opts ExecutionOptions) (map[string]bool, error) {
+	if opts.StartFromNode == "" && opts.StopAtNode == "" {
+		return nil, nil
+	}
+
+	scope := make(map[string]bool)
+	if opts.StartFromNode != "" {
+		if dag.GetNode(opts.StartFromNode) == nil {
+			return nil, fmt.Errorf("start node %s not found", opts.StartFromNode)
+		}
+		for nodeID := range dag.SubDAG(opts.StartFromNode).Nodes {
+			scope[nodeID] = true
+		}
+	} else {
+		for nodeID := range dag.Nodes {
+			scope[nodeID] = true
+		}
+	}
+
+	if opts.StopAtNode != "" {
+		if dag.GetNode(opts.StopAtNode) == nil {
+			return nil, fmt.Errorf("stop node %s not found", opts.StopAtNode)
+		}
+		upstream := dag.Ancestors(opts.StopAtNode)
+		upstream[opts.StopAtNode] = true
+		for nodeID := range scope {
+			if !upstream[nodeID] {
+				delete(scope, nodeID)
+			}
+		}
+		if len(scope) == 0 {
+			return nil, fmt.Errorf("stop node %s is not downstream of start node %s", opts.StopAtNode, opts.StartFromNode)
+		}
+	}
+
+	return scope, nil
+}
+
+// seedOutputs restores the outputs of nodes outside the run scope from a
+// previous execution, so the nodes that do run see the same upstream data
+// without calling upstream services again. It fails when an upstream node
+// that ran has no usable output, rather than run nodes against missing or
+// redacted data.
+func (p *Processor) seedOutputs(rctx *RuntimeContext, dag *DAG, opts ExecutionOptions) error {
+	if rctx.scope == nil {
+		return nil
+	}
+
+	upstream := make(map[string]bool)
+	for nodeID := range rctx.scope {
+		for ancestor := range dag.Ancestors(nodeID) {
+			if !rctx.InScope(ancestor) {
+				upstream[ancestor] = true
+			}
+		}
+	}
+
+	for nodeID, output := range opts.SeedOutputs {
+		node := dag.GetNode(nodeID)
+		if node == nil || rctx.InScope(nodeID) {
+			continue
+		}
+		if upstream[nodeID] {
+			if output == nil {
+				return fmt.Errorf("output of node %s was not saved by the original execution", nodeID)
+			}
+			if containsMasked(output) {
+				return fmt.Errorf("output of node %s was redacted in the original execution", nodeID)
+			}
+		}
+
+		if handler := core.Get(node.Type); handler != nil {
+			rctx.SetNodeRoutes(nodeID, resolveRoutes(handler, output))
+		}
+		rctx.replaceNodeOutput(nodeID, output)
+		rctx.SetNodeResult(&NodeResult{
+			NodeID:   node.ID,
+			NodeType: node.Type,
+			NodeName: node.Name,
+			Status:   NodeStatusCached,
+			Output:   output,
+			Cached:   true,
+		})
+	}
+
+	// Upstream nodes without a seed must not have run in the original
+	// execution either, i.e. none of their inputs was active
+	for nodeID := range upstream {
+		if _, ok := opts.SeedOutputs[nodeID]; ok {
+			continue
+		}
+		if node := dag.GetNode(nodeID); node != nil && shouldExecute(rctx, node) {
+			return fmt.Errorf("no output of node %s was recorded by the original execution", nodeID)
+		}
+	}
+	return nil
+}
+
+// containsMasked reports whether a value holds a redacted secret
+func containsMasked(v interface{}) bool {
+	switch val := v.(type) {
+	case string:
+		return strings.Contains(val, MaskedValue)
+	case map[string]interface{}:
+		for _, item := range val {
+			if containsMasked(item) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, item := range val {
+			if containsMasked(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// InScope reports whether a node runs in this execution
+func (rctx *RuntimeContext) InScope(nodeID string) bool {
+	return rctx.scope == nil || rctx.scope[nodeID]
+}
@@ -0,0 +1,85 @@
+package processor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+)
+
+// PauseSignal asks a running execution to pause. The nodes already running
+// finish; no new node is started.
+type PauseSignal struct {
+	requested chan struct{}
+}
+
+// NewPauseSignal creates a pause signal
+func NewPauseSignal() *PauseSignal {
+	return &PauseSignal{requested: make(chan struct{})}
+}
+
+// Pause requests the pause. Requesting it again has no effect.
+func (s *PauseSignal) Pause() {
+	select {
+	case <-s.requested:
+	default:
+		close(s.requested)
+	}
+}
+
+// Requested reports whether the pause was requested
+func (s *PauseSignal) Requested() bool {
+	if s == nil {
+		return false
+	}
+	select {
+	case <-s.requested:
+		return true
+	default:
+		return false
+	}
+}
+
+// markPaused records that the execution stopped on a pause request
+func (rctx *RuntimeContext) markPaused() {
+	rctx.paused.Store(true)
+}
+
+// Paused reports whether the execution stopped on a pause request
+func (rctx *RuntimeContext) Paused() bool {
+	return rctx.paused.Load()
+}
+
+// PausedStateFromMap restores the state of a paused execution stored with
+// ToMap
+func PausedStateFromMap(m map[string]interface{}) (*ExecutionState, error) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read execution state: %w", err)
+	}
+	var state ExecutionState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to read execution state: %w", err)
+	}
+	return &state, nil
+}
+
+// continuePaused restores the progress of a paused execution and checkpoints
+// it right away, so a crash before the next node finishes doesn't lose it
+func (p *Processor) continuePaused(ctx context.Context, rctx *RuntimeContext, state *ExecutionState) {
+	restoreProgress(rctx, state)
+	log.Info().
+		Str("execution_id", rctx.ExecutionID.String()).
+		Int("nodes_restored", len(state.NodeResults)).
+		Msg("Execution continued from pause")
+
+	if p.checkpointer == nil {
+		return
+	}
+	if err := p.checkpointer.Save(ctx, rctx.ExecutionID, rctx.checkpointState()); err != nil {
+		log.Warn().Err(err).
+			Str("execution_id", rctx.ExecutionID.String()).
+			Msg("Failed to save execution checkpoint")
+	}
+}
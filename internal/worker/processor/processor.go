@@ -2,8 +2,8 @@ package processor
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -28,6 +28,10 @@ type Processor struct {
 	middleware MiddlewareChain
 	cache      Cache
 	metrics    MetricsCollector
+	recorder   NodeRecorder
+
+	checkpointer       Checkpointer
+	checkpointInterval time.Duration
 }
 
 // Cache interface for result caching
@@ -42,19 +46,38 @@ type MetricsCollector interface {
 	RecordWorkflowExecution(workspaceID string, duration time.Duration, nodesCount int, err error)
 }
 
+// NodeRecorder persists the result of every node that ran or was skipped.
+// Record must not block; implementations are expected to buffer and write
+// asynchronously. Flush writes everything recorded for an execution.
+type NodeRecorder interface {
+	Record(executionID uuid.UUID, result *NodeResult)
+	Flush(ctx context.Context, executionID uuid.UUID) error
+}
+
 // Config configures the processor
 type Config struct {
 	Middleware MiddlewareChain
 	Cache      Cache
 	Metrics    MetricsCollector
+	Recorder   NodeRecorder
+	// Checkpointer saves the progress of executions for crash recovery
+	Checkpointer       Checkpointer
+	CheckpointInterval time.Duration
 }
 
 // New creates a new processor
 func New(cfg Config) *Processor {
+	if cfg.CheckpointInterval <= 0 {
+		cfg.CheckpointInterval = DefaultCheckpointInterval
+	}
+
 	return &Processor{
-		middleware: cfg.Middleware,
-		cache:      cfg.Cache,
-		metrics:    cfg.Metrics,
+		middleware:         cfg.Middleware,
+		cache:              cfg.Cache,
+		metrics:            cfg.Metrics,
+		recorder:           cfg.Recorder,
+		checkpointer:       cfg.Checkpointer,
+		checkpointInterval: cfg.CheckpointInterval,
 	}
 }
 
@@ -72,6 +95,12 @@ func (p *Processor) Execute(
 
 	// Create runtime context
 	rctx := NewRuntimeContext(ctx, executionID, workflow.ID, workflow.WorkspaceID, input, getCredential, publisher)
+	rctx.setWorkflow(workflow)
+	rctx.setEnv(opts.Env)
+
+	if opts.RetryPolicy == nil {
+		opts.RetryPolicy = workflow.Settings.RetryPolicy
+	}
 
 	// Apply workflow timeout
 	if opts.WorkflowTimeout > 0 {
@@ -96,6 +125,40 @@ func (p *Processor) Execute(
 		}, nil
 	}
 
+	// Restrict a partial run and restore the outputs it builds on
+	scope, err := executionScope(dag, opts)
+	if err != nil {
+		return &Result{
+			ExecutionID: executionID,
+			Status:      StatusFailed,
+			Error:       err.Error(),
+			StartedAt:   startTime,
+			CompletedAt: time.Now(),
+			Duration:    time.Since(startTime),
+		}, nil
+	}
+	if scope != nil {
+		rctx.scope = scope
+		rctx.SetTotalNodes(len(scope))
+	}
+	if err := p.seedOutputs(rctx, dag, opts); err != nil {
+		return &Result{
+			ExecutionID: executionID,
+			Status:      StatusFailed,
+			Error:       fmt.Sprintf("cannot replay: %s", err),
+			StartedAt:   startTime,
+			CompletedAt: time.Now(),
+			Duration:    time.Since(startTime),
+		}, nil
+	}
+	if opts.Resume != nil {
+		p.restoreState(rctx, dag, opts.Resume)
+	} else if opts.Recover {
+		p.recoverCheckpoint(ctx, rctx)
+	} else if opts.Paused != nil {
+		p.continuePaused(ctx, rctx, opts.Paused)
+	}
+
 	// Execute workflow
 	var execErr error
 	if opts.MaxParallelNodes > 1 {
@@ -111,22 +174,64 @@ func (p *Processor) Execute(
 		CompletedAt:   time.Now(),
 		Duration:      time.Since(startTime),
 		NodesExecuted: int(rctx.completedNodes.Load()),
-		NodeResults:   make(map[string]*NodeResult),
+		NodeResults:   rctx.GetAllNodeResults(),
 		Output:        rctx.GetAllNodeOutputs(),
 	}
 
-	if execErr != nil {
+	if suspension, nodeID := rctx.Suspension(); suspension != nil {
+		// Waiting executions release the worker and continue from this state
+		result.Status = StatusWaiting
+		result.State = rctx.snapshot()
+		log.Info().
+			Str("execution_id", executionID.String()).
+			Str("node_id", nodeID).
+			Time("resume_at", suspension.ResumeAt).
+			Msg("Execution suspended")
+	} else if execErr != nil {
 		result.Status = StatusFailed
 		result.Error = execErr.Error()
+		result.ErrorDetails = core.ToNodeError(execErr)
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			result.Status = StatusTimedOut
+			result.TimedOut = true
+			result.Error = fmt.Sprintf("execution timed out after %s: %s", opts.WorkflowTimeout, result.Error)
+		}
 		if err, nodeID := rctx.GetError(); err != nil {
 			result.ErrorNodeID = nodeID
 		}
 	} else if rctx.IsCancelled() {
 		result.Status = StatusCancelled
+	} else if rctx.Paused() {
+		// Paused executions release the worker and continue from the nodes
+		// they finished
+		result.Status = StatusPaused
+		result.State = rctx.checkpointState()
+		log.Info().
+			Str("execution_id", executionID.String()).
+			Int("nodes_finished", len(result.State.NodeResults)).
+			Msg("Execution paused")
 	} else {
 		result.Status = StatusCompleted
 	}
 
+	// Secrets stay in the state an execution continues from, but not in what
+	// is stored and reported
+	rctx.redactResult(result)
+
+	// The execution no longer needs to be recovered
+	if p.checkpointer != nil {
+		if err := p.checkpointer.Delete(context.WithoutCancel(ctx), executionID); err != nil {
+			log.Warn().Err(err).Str("execution_id", executionID.String()).Msg("Failed to delete execution checkpoint")
+		}
+	}
+
+	// Persist node records before the execution is reported as finished
+	if p.recorder != nil {
+		if err := p.recorder.Flush(context.WithoutCancel(ctx), executionID); err != nil {
+			log.Error().Err(err).Str("execution_id", executionID.String()).Msg("Failed to persist node executions")
+		}
+	}
+
 	// Record metrics
 	if p.metrics != nil {
 		p.metrics.RecordWorkflowExecution(workflow.WorkspaceID.String(), result.Duration, result.NodesExecuted, execErr)
@@ -158,71 +263,129 @@ func (p *Processor) executeSequential(ctx context.Context, rctx *RuntimeContext,
 			continue
 		}
 
-		if err := p.executeNode(ctx, rctx, node, opts); err != nil {
+		// Loop body nodes have already run as part of their loop
+		if _, done := rctx.GetNodeResult(nodeID); done {
+			continue
+		}
+
+		if !shouldExecute(rctx, node) {
+			p.markSkipped(rctx, node)
+			p.checkpoint(ctx, rctx, dag, node)
+			continue
+		}
+
+		if opts.Pause.Requested() {
+			rctx.markPaused()
+			return nil
+		}
+
+		if err := p.runNode(ctx, rctx, dag, node, opts); err != nil {
 			rctx.SetError(err, nodeID)
 			return err
 		}
+		p.checkpoint(ctx, rctx, dag, node)
 	}
 
 	return nil
 }
 
-// executeParallel executes nodes in parallel where possible
+// executeParallel starts every node as soon as all its predecessors have
+// finished, running up to MaxParallelNodes at a time, so a slow node only
+// holds up the nodes that depend on it. After a failure no more nodes are
+// started and the nodes already running are waited for.
 func (p *Processor) executeParallel(ctx context.Context, rctx *RuntimeContext, dag *DAG, opts ExecutionOptions) error {
-	levels, err := dag.GetLevels()
-	if err != nil {
+	if _, err := dag.TopologicalSort(); err != nil {
 		return err
 	}
 
-	semaphore := make(chan struct{}, opts.MaxParallelNodes)
-
-	for _, level := range levels {
-		if rctx.IsCancelled() {
-			return fmt.Errorf("execution cancelled")
+	// Remaining incoming edges per node; a node is ready when it reaches zero
+	pending := make(map[string]int, len(dag.InDegree))
+	for nodeID, degree := range dag.InDegree {
+		pending[nodeID] = degree
+	}
+	ready := append([]string(nil), dag.RootNodes...)
+	release := func(nodeID string) {
+		for _, target := range dag.Edges[nodeID] {
+			pending[target]--
+			if pending[target] == 0 {
+				ready = append(ready, target)
+			}
 		}
+	}
 
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		default:
-		}
+	type nodeDone struct {
+		nodeID string
+		err    error
+	}
+	doneCh := make(chan nodeDone)
+	running := 0
+	var execErr error
 
-		// Execute all nodes in this level in parallel
-		var wg sync.WaitGroup
-		errChan := make(chan error, len(level))
+	for {
+		for execErr == nil && len(ready) > 0 && running < opts.MaxParallelNodes {
+			if rctx.IsCancelled() {
+				execErr = fmt.Errorf("execution cancelled")
+				break
+			}
+			if err := ctx.Err(); err != nil {
+				execErr = err
+				break
+			}
 
-		for _, nodeID := range level {
+			nodeID := ready[0]
+			ready = ready[1:]
 			node := dag.GetNode(nodeID)
 			if node == nil {
 				continue
 			}
 
-			wg.Add(1)
-			semaphore <- struct{}{} // Acquire
+			// Loop body nodes have already run as part of their loop
+			if _, done := rctx.GetNodeResult(nodeID); done {
+				release(nodeID)
+				continue
+			}
+
+			// All predecessors have finished, so routing is settled
+			if !shouldExecute(rctx, node) {
+				p.markSkipped(rctx, node)
+				p.checkpoint(ctx, rctx, dag, node)
+				release(nodeID)
+				continue
+			}
 
-			go func(n *NodeDefinition) {
-				defer wg.Done()
-				defer func() { <-semaphore }() // Release
+			// A paused execution starts no more nodes and continues with
+			// this one
+			if opts.Pause.Requested() {
+				rctx.markPaused()
+				break
+			}
 
-				if err := p.executeNode(ctx, rctx, n, opts); err != nil {
+			running++
+			go func(n *NodeDefinition) {
+				err := p.runNode(ctx, rctx, dag, n, opts)
+				if err != nil {
 					rctx.SetError(err, n.ID)
-					errChan <- err
+				} else {
+					p.checkpoint(ctx, rctx, dag, n)
 				}
+				doneCh <- nodeDone{nodeID: n.ID, err: err}
 			}(node)
 		}
 
-		wg.Wait()
-		close(errChan)
+		if running == 0 {
+			return execErr
+		}
 
-		// Check for errors
-		for err := range errChan {
-			if err != nil {
-				return err
+		done := <-doneCh
+		running--
+		if done.err != nil {
+			if execErr == nil {
+				execErr = done.err
 			}
+			continue
 		}
+		release(done.nodeID)
 	}
-
-	return nil
 }
 
 // executeNode executes a single node
@@ -239,6 +402,13 @@ func (p *Processor) executeNode(ctx context.Context, rctx *RuntimeContext, node
 		}
 	}
 
+	// Nodes outside a partial run are not executed
+	if !rctx.InScope(node.ID) {
+		return nil
+	}
+
+	rctx.startRun(node.ID)
+
 	log.Debug().
 		Str("execution_id", rctx.ExecutionID.String()).
 		Str("node_id", node.ID).
@@ -250,28 +420,65 @@ func (p *Processor) executeNode(ctx context.Context, rctx *RuntimeContext, node
 
 	startTime := time.Now()
 
+	// Substitute pinned output instead of calling the node
+	if pinned, ok := opts.PinnedData[node.ID]; ok {
+		p.usePinnedData(rctx, node, pinned, startTime)
+		return nil
+	}
+
 	// Prepare input
 	nodeInput := rctx.PrepareNodeInput(node)
 
-	// Resolve expressions in config
-	resolvedConfig, err := rctx.ResolveConfig(node.Config)
-	if err != nil {
-		rctx.PublishNodeFailed(node, err.Error())
-		return fmt.Errorf("failed to resolve config: %w", err)
-	}
-
-	// Apply overrides
-	if overrides, ok := opts.NodeOverrides[node.ID]; ok {
-		for k, v := range overrides {
-			resolvedConfig[k] = v
+	perItem := node.ExecutionMode == core.ModeOnceForEachItem
+
+	// Resolve expressions in config; per-item nodes resolve it for each item
+	var resolvedConfig map[string]interface{}
+	if !perItem {
+		var err error
+		resolvedConfig, err = rctx.ResolveNodeConfig(node, node.Config)
+		if err != nil {
+			nodeErr := core.NewConfigError("failed to resolve config", err)
+			rctx.SetNodeResult(&NodeResult{
+				NodeID:       node.ID,
+				NodeType:     node.Type,
+				NodeName:     node.Name,
+				Status:       NodeStatusFailed,
+				Error:        nodeErr.Error(),
+				ErrorDetails: nodeErr,
+				StartedAt:    startTime,
+				CompletedAt:  time.Now(),
+				Duration:     time.Since(startTime),
+			})
+			rctx.PublishNodeFailed(node, nodeErr)
+			return nodeErr
 		}
+
+		// Apply overrides
+		applyOverrides(resolvedConfig, node, opts)
 	}
 
+	cacheable := opts.EnableCaching && p.cache != nil && isCacheable(node.Type) && !perItem && !opts.DryRun
+
 	// Check cache
-	if opts.EnableCaching && p.cache != nil && isCacheable(node.Type) {
+	if cacheable {
 		cacheKey := fmt.Sprintf("%s:%s:%s", rctx.ExecutionID, node.ID, rctx.ComputeInputHash(node.ID, nodeInput))
 		if cached, ok := p.cache.Get(ctx, cacheKey); ok {
+			if handler := core.Get(node.Type); handler != nil {
+				rctx.SetNodeRoutes(node.ID, resolveRoutes(handler, cached))
+			}
 			rctx.SetNodeOutput(node.ID, cached)
+			rctx.SetNodeResult(&NodeResult{
+				NodeID:      node.ID,
+				NodeType:    node.Type,
+				NodeName:    node.Name,
+				Status:      NodeStatusCached,
+				Input:       recordedInput(resolvedConfig, rctx.NodeItems(node)),
+				Output:      cached,
+				StartedAt:   startTime,
+				CompletedAt: time.Now(),
+				Duration:    time.Since(startTime),
+				Cached:      true,
+			})
 			durationMs := int(time.Since(startTime).Milliseconds())
 			rctx.PublishNodeCompleted(node, durationMs, cached)
 			return nil
@@ -281,49 +488,35 @@ func (p *Processor) executeNode(ctx context.Context, rctx *RuntimeContext, node
 	// Get node handler
 	handler := core.Get(node.Type)
 	if handler == nil {
-		err := fmt.Errorf("unknown node type: %s", node.Type)
-		rctx.PublishNodeFailed(node, err.Error())
+		err := core.NewConfigError(fmt.Sprintf("unknown node type: %s", node.Type), nil)
+		rctx.PublishNodeFailed(node, err)
 		return err
 	}
 
-	// Build execution context for node
-	nodeExecCtx := &core.ExecutionContext{
-		ExecutionID:   rctx.ExecutionID,
-		WorkflowID:    rctx.WorkflowID,
-		WorkspaceID:   rctx.WorkspaceID,
-		NodeID:        node.ID,
-		Input:         nodeInput,
-		Config:        resolvedConfig,
-		Variables:     rctx.Variables,
-		GetCredential: rctx.GetCredential,
-	}
-
-	// Apply node timeout
-	nodeCtx := ctx
-	timeout := opts.DefaultNodeTimeout
-	if node.Timeout > 0 {
-		timeout = node.Timeout
-	}
-	if timeout > 0 {
-		var cancel context.CancelFunc
-		nodeCtx, cancel = context.WithTimeout(ctx, timeout)
-		defer cancel()
-	}
+	items := rctx.NodeItems(node)
 
-	// Execute with middleware chain
 	var output map[string]interface{}
+	var routes []string
+	var retries int
 	var execErr error
 
-	if p.middleware != nil {
-		output, execErr = p.middleware.Execute(nodeCtx, rctx, node, func(ctx context.Context) (*NodeResult, error) {
-			out, err := handler.Execute(ctx, nodeExecCtx)
-			if err != nil {
-				return nil, err
-			}
-			return &NodeResult{Output: out}, nil
-		})
+	if perItem {
+		output, routes, retries, execErr = p.executeForEachItem(ctx, rctx, node, handler, nodeInput, items, opts)
 	} else {
-		output, execErr = handler.Execute(nodeCtx, nodeExecCtx)
+		output, retries, execErr = p.invokeNode(ctx, rctx, node, handler, &core.ExecutionContext{
+			ExecutionID:   rctx.ExecutionID,
+			WorkflowID:    rctx.WorkflowID,
+			WorkspaceID:   rctx.WorkspaceID,
+			NodeID:        node.ID,
+			Input:         nodeInput,
+			Items:         items,
+			Config:        resolvedConfig,
+			Variables:     rctx.Variables,
+			GetCredential: rctx.GetCredential,
+		}, opts)
+		if execErr == nil {
+			routes = resolveRoutes(handler, output)
+		}
 	}
 
 	durationMs := int(time.Since(startTime).Milliseconds())
@@ -333,34 +526,86 @@ func (p *Processor) executeNode(ctx context.Context, rctx *RuntimeContext, node
 		p.metrics.RecordNodeExecution(rctx.WorkspaceID.String(), node.Type, time.Since(startTime), execErr)
 	}
 
-	// Handle retry on fail
-	if execErr != nil && node.RetryOnFail && node.MaxRetries > 0 {
-		for retry := 1; retry <= node.MaxRetries; retry++ {
-			log.Debug().
-				Str("node_id", node.ID).
-				Int("retry", retry).
-				Msg("Retrying node execution")
-
-			// Exponential backoff
-			time.Sleep(time.Duration(retry*retry) * 100 * time.Millisecond)
+	if suspension, ok := core.AsSuspension(execErr); ok {
+		// Loop iterations can't be resumed midway, so waits there must stay inline
+		if rctx.LoopScope(node.ID) == nil {
+			rctx.suspend(node.ID, suspension)
+			rctx.SetNodeResult(&NodeResult{
+				NodeID:    node.ID,
+				NodeType:  node.Type,
+				NodeName:  node.Name,
+				Status:    NodeStatusWaiting,
+				Input:     recordedInput(resolvedConfig, items),
+				StartedAt: startTime,
+			})
+			return execErr
+		}
+		execErr = fmt.Errorf("node cannot suspend the execution inside a loop: %w", execErr)
+	}
 
-			output, execErr = handler.Execute(nodeCtx, nodeExecCtx)
-			if execErr == nil {
-				break
-			}
+	// Nodes that handle their own errors keep the execution going, unless
+	// it was cancelled or timed out
+	if execErr != nil && node.handlesError() && ctx.Err() == nil {
+		output = errorOutput(node, nodeInput, execErr)
+		status := NodeStatusCompleted
+		if node.OnError == OnErrorRoute {
+			status = NodeStatusFailed
 		}
+		rctx.SetNodeOutput(node.ID, output)
+		rctx.SetNodeResult(&NodeResult{
+			NodeID:       node.ID,
+			NodeType:     node.Type,
+			NodeName:     node.Name,
+			Status:       status,
+			Input:        recordedInput(resolvedConfig, items),
+			Output:       output,
+			Error:        execErr.Error(),
+			ErrorDetails: core.ToNodeError(execErr),
+			StartedAt:    startTime,
+			CompletedAt:  time.Now(),
+			Duration:     time.Since(startTime),
+			Retries:      retries,
+		})
+		rctx.PublishNodeFailed(node, execErr)
+		return nil
 	}
 
 	if execErr != nil {
-		rctx.PublishNodeFailed(node, execErr.Error())
+		rctx.SetNodeResult(&NodeResult{
+			NodeID:       node.ID,
+			NodeType:     node.Type,
+			NodeName:     node.Name,
+			Status:       NodeStatusFailed,
+			Input:        recordedInput(resolvedConfig, items),
+			Error:        execErr.Error(),
+			ErrorDetails: core.ToNodeError(execErr),
+			StartedAt:    startTime,
+			CompletedAt:  time.Now(),
+			Duration:     time.Since(startTime),
+			Retries:      retries,
+		})
+		rctx.PublishNodeFailed(node, execErr)
 		return execErr
 	}
 
-	// Store output
+	// Store output and the branches it selected
+	rctx.SetNodeRoutes(node.ID, routes)
 	rctx.SetNodeOutput(node.ID, output)
+	rctx.SetNodeResult(&NodeResult{
+		NodeID:      node.ID,
+		NodeType:    node.Type,
+		NodeName:    node.Name,
+		Status:      NodeStatusCompleted,
+		Input:       recordedInput(resolvedConfig, items),
+		Output:      output,
+		StartedAt:   startTime,
+		CompletedAt: time.Now(),
+		Duration:    time.Since(startTime),
+		Retries:     retries,
+	})
 
 	// Cache result
-	if opts.EnableCaching && p.cache != nil && isCacheable(node.Type) {
+	if cacheable {
 		cacheKey := fmt.Sprintf("%s:%s:%s", rctx.ExecutionID, node.ID, rctx.ComputeInputHash(node.ID, nodeInput))
 		_ = p.cache.Set(ctx, cacheKey, output)
 	}
@@ -369,9 +614,124 @@ func (p *Processor) executeNode(ctx context.Context, rctx *RuntimeContext, node
 	return nil
 }
 
-// Preview performs a dry-run validation of the workflow
+// usePinnedData completes a node with its pinned output
+func (p *Processor) usePinnedData(rctx *RuntimeContext, node *NodeDefinition, pinned map[string]interface{}, startTime time.Time) {
+	if handler := core.Get(node.Type); handler != nil {
+		rctx.SetNodeRoutes(node.ID, resolveRoutes(handler, pinned))
+	}
+	rctx.SetNodeOutput(node.ID, pinned)
+	rctx.SetNodeResult(&NodeResult{
+		NodeID:      node.ID,
+		NodeType:    node.Type,
+		NodeName:    node.Name,
+		Status:      NodeStatusCompleted,
+		Output:      pinned,
+		StartedAt:   startTime,
+		CompletedAt: time.Now(),
+		Duration:    time.Since(startTime),
+		Pinned:      true,
+	})
+	rctx.PublishNodePinned(node, pinned)
+}
+
+// recordNode hands the latest result of a node to the recorder
+func (p *Processor) recordNode(rctx *RuntimeContext, nodeID string) {
+	if p.recorder == nil || rctx.restored[nodeID] {
+		return
+	}
+	if result, ok := rctx.GetNodeResult(nodeID); ok {
+		result = rctx.redactNodeResult(result)
+		if rctx.discardNodeData {
+			stripped := *result
+			stripped.Input, stripped.Output = nil, nil
+			result = &stripped
+		}
+		p.recorder.Record(rctx.ExecutionID, result)
+	}
+}
+
+// invokeNode runs a node handler through the middleware chain with the node's
+// timeout, retrying on failure when the node asks for it. It returns the
+// number of retries that were made.
+func (p *Processor) invokeNode(ctx context.Context, rctx *RuntimeContext, node *NodeDefinition, handler core.Node, nodeExecCtx *core.ExecutionContext, opts ExecutionOptions) (map[string]interface{}, int, error) {
+	// Dry runs describe side effects instead of performing them
+	if opts.DryRun && core.HasSideEffects(handler, nodeExecCtx.Config) {
+		log.Debug().
+			Str("execution_id", rctx.ExecutionID.String()).
+			Str("node_id", node.ID).
+			Msg("Simulating side-effect node")
+		output, err := core.SimulateSideEffect(ctx, handler, nodeExecCtx)
+		return output, 0, err
+	}
+
+	output, execErr := p.attemptNode(ctx, rctx, node, handler, nodeExecCtx, opts)
+
+	// Retry according to the node's policy; each attempt goes through the
+	// middleware chain with a fresh timeout
+	policy := retryPolicy(node, opts)
+	retries := 0
+	for policy != nil && execErr != nil && retries < policy.MaxRetries {
+		if _, suspended := core.AsSuspension(execErr); suspended || ctx.Err() != nil || !policy.ShouldRetry(execErr) {
+			break
+		}
+
+		retries++
+		delay := policy.Delay(retries, execErr)
+		log.Debug().
+			Str("node_id", node.ID).
+			Int("retry", retries).
+			Dur("delay", delay).
+			Str("error", rctx.RedactString(execErr.Error())).
+			Msg("Retrying node execution")
+		rctx.PublishNodeRetrying(node, retries, delay, execErr.Error())
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return output, retries, execErr
+		case <-timer.C:
+		}
+
+		output, execErr = p.attemptNode(ctx, rctx, node, handler, nodeExecCtx, opts)
+	}
+
+	return output, retries, execErr
+}
+
+// attemptNode runs a node handler once through the middleware chain with the
+// node's timeout
+func (p *Processor) attemptNode(ctx context.Context, rctx *RuntimeContext, node *NodeDefinition, handler core.Node, nodeExecCtx *core.ExecutionContext, opts ExecutionOptions) (map[string]interface{}, error) {
+	timeout := opts.DefaultNodeTimeout
+	if node.Timeout > 0 {
+		timeout = node.Timeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	if p.middleware == nil {
+		return handler.Execute(ctx, nodeExecCtx)
+	}
+	return p.middleware.Execute(ctx, rctx, node, func(ctx context.Context) (*NodeResult, error) {
+		out, err := handler.Execute(ctx, nodeExecCtx)
+		if err != nil {
+			return nil, err
+		}
+		return &NodeResult{Output: out}, nil
+	})
+}
+
+// Preview performs a dry-run validation of the workflow. Nodes are not
+// executed, so expressions referencing upstream outputs resolve to empty
+// values in the returned inputs.
 func (p *Processor) Preview(ctx context.Context, workflow *WorkflowDefinition, input Input) (*PreviewResult, error) {
 	dag := BuildDAG(workflow)
+	rctx := NewRuntimeContext(ctx, uuid.Nil, workflow.ID, workflow.WorkspaceID, input, nil, nil)
+	rctx.setWorkflow(workflow)
+	defer rctx.Cancel()
 
 	result := &PreviewResult{
 		Valid:        true,
@@ -411,7 +771,8 @@ func (p *Processor) Preview(ctx context.Context, workflow *WorkflowDefinition, i
 		}
 
 		// Check if node type exists
-		if core.Get(node.Type) == nil {
+		handler := core.Get(node.Type)
+		if handler == nil {
 			result.Errors = append(result.Errors, ValidationError{
 				NodeID:  nodeID,
 				Message: fmt.Sprintf("Unknown node type: %s", node.Type),
@@ -420,12 +781,39 @@ func (p *Processor) Preview(ctx context.Context, workflow *WorkflowDefinition, i
 			result.Valid = false
 		}
 
+		if preview.WouldExecute {
+			config, err := previewConfig(rctx, node)
+			if err != nil {
+				result.Warnings = append(result.Warnings, ValidationWarning{
+					NodeID:  nodeID,
+					Field:   "config",
+					Message: err.Error(),
+					Code:    "UNRESOLVED_EXPRESSION",
+				})
+			}
+			preview.ResolvedInput = recordedInput(config, rctx.NodeItems(node))
+			if handler != nil && config != nil {
+				preview.SideEffects = core.HasSideEffects(handler, config)
+			}
+		}
+
 		result.NodePreviews[nodeID] = preview
 	}
 
 	return result, nil
 }
 
+// previewConfig resolves a node's config for Preview. Per-item nodes are
+// resolved against their first input item.
+func previewConfig(rctx *RuntimeContext, node *NodeDefinition) (map[string]interface{}, error) {
+	if node.ExecutionMode == core.ModeOnceForEachItem {
+		if items := rctx.NodeItems(node); len(items) > 0 {
+			return rctx.ResolveItemConfig(node, node.Config, items[0], 0)
+		}
+	}
+	return rctx.ResolveNodeConfig(node, node.Config)
+}
+
 // isCacheable determines if a node type is safe to cache
 func isCacheable(nodeType string) bool {
 	nonCacheable := map[string]bool{
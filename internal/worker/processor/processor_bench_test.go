@@ -0,0 +1,185 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/linkflow-ai/linkflow/internal/worker/core"
+	"github.com/rs/zerolog"
+)
+
+const sleepNodeType = "test.sleep"
+
+// sleepNode sleeps for its "delay" (ms) and fails when "fail" is set. It
+// counts the nodes started and running, so tests can see what a scheduler
+// started and whether it waited for it.
+type sleepNode struct {
+	started atomic.Int64
+	running atomic.Int64
+}
+
+var sleeper = &sleepNode{}
+
+func init() {
+	core.Register(sleeper)
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+}
+
+func (n *sleepNode) Type() string {
+	return sleepNodeType
+}
+
+func (n *sleepNode) Execute(ctx context.Context, execCtx *core.ExecutionContext) (map[string]interface{}, error) {
+	n.started.Add(1)
+	n.running.Add(1)
+	defer n.running.Add(-1)
+
+	time.Sleep(time.Duration(core.GetInt(execCtx.Config, "delay", 0)) * time.Millisecond)
+	if core.GetBool(execCtx.Config, "fail", false) {
+		return nil, errors.New("sleep node failed")
+	}
+	return map[string]interface{}{"ok": true}, nil
+}
+
+func (n *sleepNode) reset() {
+	n.started.Store(0)
+	n.running.Store(0)
+}
+
+// chainedWorkflow builds a root node fanning out into width chains of depth
+// nodes. delay returns the delay of the node at a chain and level.
+func chainedWorkflow(width, depth int, delay func(chain, level int) int) *WorkflowDefinition {
+	wf := &WorkflowDefinition{
+		ID:          uuid.New(),
+		WorkspaceID: uuid.New(),
+		Nodes:       []*NodeDefinition{{ID: "root", Type: sleepNodeType, Config: map[string]interface{}{}}},
+	}
+	for chain := 0; chain < width; chain++ {
+		prev := "root"
+		for level := 0; level < depth; level++ {
+			id := chainNodeID(chain, level)
+			wf.Nodes = append(wf.Nodes, &NodeDefinition{
+				ID:     id,
+				Type:   sleepNodeType,
+				Config: map[string]interface{}{"delay": delay(chain, level)},
+			})
+			wf.Connections = append(wf.Connections, &Connection{
+				ID:           prev + "-" + id,
+				SourceNodeID: prev,
+				TargetNodeID: id,
+			})
+			prev = id
+		}
+	}
+	return wf
+}
+
+func chainNodeID(chain, level int) string {
+	return fmt.Sprintf("c%d-l%d", chain, level)
+}
+
+// newSchedulerRun prepares what Execute hands to a scheduler
+func newSchedulerRun(wf *WorkflowDefinition) (*Processor, *RuntimeContext, *DAG) {
+	rctx := NewRuntimeContext(context.Background(), uuid.New(), wf.ID, wf.WorkspaceID, Input{}, nil, nil)
+	rctx.setWorkflow(wf)
+	dag := BuildDAG(wf)
+	rctx.SetTotalNodes(dag.NodeCount())
+	return New(Config{}), rctx, dag
+}
+
+// executeLevels is the level barrier executeParallel replaced: every level
+// starts only once all nodes of the previous one have finished
+func (p *Processor) executeLevels(ctx context.Context, rctx *RuntimeContext, dag *DAG, opts ExecutionOptions) error {
+	levels, err := dag.GetLevels()
+	if err != nil {
+		return err
+	}
+
+	semaphore := make(chan struct{}, opts.MaxParallelNodes)
+
+	for _, level := range levels {
+		if rctx.IsCancelled() {
+			return fmt.Errorf("execution cancelled")
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		var wg sync.WaitGroup
+		errChan := make(chan error, len(level))
+
+		for _, nodeID := range level {
+			node := dag.GetNode(nodeID)
+			if node == nil {
+				continue
+			}
+			if !shouldExecute(rctx, node) {
+				p.markSkipped(rctx, node)
+				continue
+			}
+
+			wg.Add(1)
+			semaphore <- struct{}{}
+
+			go func(n *NodeDefinition) {
+				defer wg.Done()
+				defer func() { <-semaphore }()
+
+				if err := p.runNode(ctx, rctx, dag, n, opts); err != nil {
+					rctx.SetError(err, n.ID)
+					errChan <- err
+				}
+			}(node)
+		}
+
+		wg.Wait()
+		close(errChan)
+
+		for err := range errChan {
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+type scheduler func(p *Processor, ctx context.Context, rctx *RuntimeContext, dag *DAG, opts ExecutionOptions) error
+
+// benchmarkScheduler runs an uneven workflow: 8 chains of 8 nodes with one
+// slow node per level, each level's slow node in a different chain
+func benchmarkScheduler(b *testing.B, schedule scheduler) {
+	wf := chainedWorkflow(8, 8, func(chain, level int) int {
+		if chain == level {
+			return 20
+		}
+		return 2
+	})
+	opts := ExecutionOptions{MaxParallelNodes: 10}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p, rctx, dag := newSchedulerRun(wf)
+		if err := schedule(p, context.Background(), rctx, dag, opts); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkLevelBarrier(b *testing.B) {
+	benchmarkScheduler(b, (*Processor).executeLevels)
+}
+
+func BenchmarkReadyQueue(b *testing.B) {
+	benchmarkScheduler(b, (*Processor).executeParallel)
+}
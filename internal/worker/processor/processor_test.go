@@ -0,0 +1,139 @@
+package processor
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// drainWorkflow has 4 chains of 3 nodes. The first level of chain 0 takes
+// 5ms and fails when fail is set; the other first-level nodes take 50ms.
+func drainWorkflow(fail bool) *WorkflowDefinition {
+	wf := chainedWorkflow(4, 3, func(chain, level int) int {
+		if level > 0 {
+			return 1
+		}
+		if chain == 0 {
+			return 5
+		}
+		return 50
+	})
+	for _, node := range wf.Nodes {
+		if node.ID == chainNodeID(0, 0) {
+			node.Config["fail"] = fail
+		}
+	}
+	return wf
+}
+
+// assertDrained checks that the nodes running when the execution stopped
+// were waited for, and that no node was started after that
+func assertDrained(t *testing.T, rctx *RuntimeContext) {
+	t.Helper()
+
+	if running := sleeper.running.Load(); running != 0 {
+		t.Fatalf("returned with %d nodes still running", running)
+	}
+	for chain := 1; chain < 4; chain++ {
+		result, ok := rctx.GetNodeResult(chainNodeID(chain, 0))
+		if !ok || result.Status != NodeStatusCompleted {
+			t.Errorf("running node %s was not waited for", chainNodeID(chain, 0))
+		}
+		if _, ok := rctx.GetNodeResult(chainNodeID(chain, 1)); ok {
+			t.Errorf("node %s was started after the execution stopped", chainNodeID(chain, 1))
+		}
+	}
+	// The root and the first level
+	if started := sleeper.started.Load(); started != 5 {
+		t.Errorf("started %d nodes, want 5", started)
+	}
+}
+
+func TestExecuteParallelDrainsAfterFailure(t *testing.T) {
+	sleeper.reset()
+	p, rctx, dag := newSchedulerRun(drainWorkflow(true))
+
+	err := p.executeParallel(context.Background(), rctx, dag, ExecutionOptions{MaxParallelNodes: 10})
+	if err == nil {
+		t.Fatal("expected the failed node's error")
+	}
+	if _, nodeID := rctx.GetError(); nodeID != chainNodeID(0, 0) {
+		t.Errorf("error recorded for node %q, want %q", nodeID, chainNodeID(0, 0))
+	}
+	assertDrained(t, rctx)
+}
+
+func TestExecuteParallelDrainsAfterCancel(t *testing.T) {
+	sleeper.reset()
+	p, rctx, dag := newSchedulerRun(drainWorkflow(false))
+
+	// Cancel while the slow first-level nodes are running, before the fast
+	// one finishes and releases its chain
+	go func() {
+		for sleeper.started.Load() < 5 {
+			time.Sleep(time.Millisecond)
+		}
+		rctx.Cancel()
+	}()
+
+	err := p.executeParallel(context.Background(), rctx, dag, ExecutionOptions{MaxParallelNodes: 10})
+	if err == nil || err.Error() != "execution cancelled" {
+		t.Fatalf("got error %v, want execution cancelled", err)
+	}
+	assertDrained(t, rctx)
+}
+
+func TestSeedOutputsRefusesUnusableSeeds(t *testing.T) {
+	wf := chainedWorkflow(1, 3, func(chain, level int) int { return 0 })
+	ok := map[string]interface{}{"ok": true}
+
+	tests := []struct {
+		name    string
+		seeds   map[string]map[string]interface{}
+		wantErr string
+	}{
+		{
+			name:  "all upstream seeded",
+			seeds: map[string]map[string]interface{}{"root": ok, chainNodeID(0, 0): ok},
+		},
+		{
+			name:    "output not saved",
+			seeds:   map[string]map[string]interface{}{"root": ok, chainNodeID(0, 0): nil},
+			wantErr: "was not saved",
+		},
+		{
+			name: "output redacted",
+			seeds: map[string]map[string]interface{}{
+				"root":            ok,
+				chainNodeID(0, 0): {"headers": map[string]interface{}{"Authorization": "Bearer " + MaskedValue}},
+			},
+			wantErr: "was redacted",
+		},
+		{
+			name:    "upstream node missing",
+			seeds:   map[string]map[string]interface{}{"root": ok},
+			wantErr: "no output of node",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, rctx, dag := newSchedulerRun(wf)
+			opts := ExecutionOptions{StartFromNode: chainNodeID(0, 1), SeedOutputs: tt.seeds}
+			scope, err := executionScope(dag, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rctx.scope = scope
+
+			err = p.seedOutputs(rctx, dag, opts)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Fatalf("got error %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package processor
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/linkflow-ai/linkflow/internal/worker/core"
+)
+
+// Backoff strategies of a RetryPolicy
+const (
+	BackoffExponential = "exponential"
+	BackoffLinear      = "linear"
+	BackoffFixed       = "fixed"
+	// BackoffQuadratic waits InitialDelay times the square of the retry
+	BackoffQuadratic = "quadratic"
+)
+
+// Retry-on rules of a RetryPolicy. Any other rule is matched against the
+// error code (core.ErrorCoder) or, if numeric, the HTTP status of the error.
+const (
+	RetryOnAny     = "any"
+	RetryOnTimeout = "timeout"
+	RetryOnNetwork = "network"
+	RetryOn5xx     = "5xx"
+	RetryOn429     = "429"
+)
+
+// RetryPolicy controls how a failed node is retried. Nodes use their own
+// policy, or the workflow's when they have none.
+type RetryPolicy struct {
+	MaxRetries   int
+	Backoff      string
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+	Multiplier   float64
+	// Jitter randomizes each delay by up to this fraction (0-1)
+	Jitter float64
+	// RetryOn lists the errors worth retrying; empty retries every error
+	// that is not a NodeError marked as not retryable
+	RetryOn []string
+}
+
+// DefaultRetryPolicy retries every error with exponential backoff
+func DefaultRetryPolicy(maxRetries int) *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries:   maxRetries,
+		Backoff:      BackoffExponential,
+		InitialDelay: time.Second,
+		MaxDelay:     time.Minute,
+		Multiplier:   2,
+		Jitter:       0.2,
+	}
+}
+
+// LegacyRetryPolicy is the policy of nodes that only set retryOnFail and
+// maxRetries: every error is retried after 100ms times the square of the
+// retry, as before retry policies existed
+func LegacyRetryPolicy(maxRetries int) *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries:   maxRetries,
+		Backoff:      BackoffQuadratic,
+		InitialDelay: 100 * time.Millisecond,
+		MaxDelay:     time.Minute,
+		RetryOn:      []string{RetryOnAny},
+	}
+}
+
+// parseRetryPolicy reads a policy from a node or the workflow settings.
+// Delays are given in milliseconds.
+func parseRetryPolicy(data map[string]interface{}) *RetryPolicy {
+	policy := DefaultRetryPolicy(getInt(data, "maxRetries", 3))
+	policy.Backoff = getString(data, "backoff", policy.Backoff)
+	if ms := getInt(data, "initialDelay", 0); ms > 0 {
+		policy.InitialDelay = time.Duration(ms) * time.Millisecond
+	}
+	if ms := getInt(data, "maxDelay", 0); ms > 0 {
+		policy.MaxDelay = time.Duration(ms) * time.Millisecond
+	}
+	policy.Multiplier = getFloat(data, "multiplier", policy.Multiplier)
+	policy.Jitter = math.Min(math.Max(getFloat(data, "jitter", policy.Jitter), 0), 1)
+	if rules, ok := data["retryOn"].([]interface{}); ok {
+		for _, rule := range rules {
+			if s, ok := rule.(string); ok {
+				policy.RetryOn = append(policy.RetryOn, s)
+			}
+		}
+	}
+	return policy
+}
+
+// ShouldRetry reports whether an error matches the policy's retry-on rules
+func (rp *RetryPolicy) ShouldRetry(err error) bool {
+	if len(rp.RetryOn) == 0 {
+		return core.IsRetryable(err)
+	}
+
+	status := core.ErrorStatusCode(err)
+	code := core.ErrorCode(err)
+	for _, rule := range rp.RetryOn {
+		switch rule {
+		case RetryOnAny:
+			return true
+		case RetryOnTimeout:
+			if core.IsTimeout(err) {
+				return true
+			}
+		case RetryOnNetwork:
+			var netErr net.Error
+			if errors.As(err, &netErr) {
+				return true
+			}
+		case RetryOn5xx:
+			if status >= 500 && status < 600 {
+				return true
+			}
+		case RetryOn429:
+			if status == http.StatusTooManyRequests {
+				return true
+			}
+		default:
+			if code != "" && rule == code {
+				return true
+			}
+			if n, convErr := strconv.Atoi(rule); convErr == nil && n == status {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Delay returns how long to wait before the given retry (1-based). A
+// Retry-After sent with a 429 or 503 response takes precedence, up to
+// MaxDelay.
+func (rp *RetryPolicy) Delay(retry int, err error) time.Duration {
+	if wait := core.ErrorRetryAfter(err); wait > 0 {
+		if rp.MaxDelay > 0 && wait > rp.MaxDelay {
+			return rp.MaxDelay
+		}
+		return wait
+	}
+
+	var delay float64
+	initial := float64(rp.InitialDelay)
+	switch rp.Backoff {
+	case BackoffFixed:
+		delay = initial
+	case BackoffLinear:
+		delay = initial * float64(retry)
+	case BackoffQuadratic:
+		delay = initial * float64(retry*retry)
+	default:
+		multiplier := rp.Multiplier
+		if multiplier < 1 {
+			multiplier = 2
+		}
+		delay = initial * math.Pow(multiplier, float64(retry-1))
+	}
+
+	if rp.Jitter > 0 {
+		delay *= 1 + rp.Jitter*(2*rand.Float64()-1)
+	}
+	if rp.MaxDelay > 0 && delay > float64(rp.MaxDelay) {
+		delay = float64(rp.MaxDelay)
+	}
+	return time.Duration(delay)
+}
+
+// retryPolicy returns the policy that applies to a node
+func retryPolicy(node *NodeDefinition, opts ExecutionOptions) *RetryPolicy {
+	if node.RetryPolicy != nil {
+		return node.RetryPolicy
+	}
+	return opts.RetryPolicy
+}
@@ -0,0 +1,278 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/linkflow-ai/linkflow/internal/domain/models"
+	"github.com/linkflow-ai/linkflow/internal/worker/core"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy RetryPolicy
+		retry  int
+		want   time.Duration
+	}{
+		{
+			name:   "exponential first retry",
+			policy: RetryPolicy{Backoff: BackoffExponential, InitialDelay: time.Second, Multiplier: 2},
+			retry:  1,
+			want:   time.Second,
+		},
+		{
+			name:   "exponential third retry",
+			policy: RetryPolicy{Backoff: BackoffExponential, InitialDelay: time.Second, Multiplier: 3},
+			retry:  3,
+			want:   9 * time.Second,
+		},
+		{
+			name:   "exponential multiplier below 1 doubles",
+			policy: RetryPolicy{Backoff: BackoffExponential, InitialDelay: time.Second, Multiplier: 0.5},
+			retry:  3,
+			want:   4 * time.Second,
+		},
+		{
+			name:   "unknown backoff is exponential",
+			policy: RetryPolicy{Backoff: "bogus", InitialDelay: time.Second, Multiplier: 2},
+			retry:  2,
+			want:   2 * time.Second,
+		},
+		{
+			name:   "linear",
+			policy: RetryPolicy{Backoff: BackoffLinear, InitialDelay: 100 * time.Millisecond},
+			retry:  3,
+			want:   300 * time.Millisecond,
+		},
+		{
+			name:   "fixed",
+			policy: RetryPolicy{Backoff: BackoffFixed, InitialDelay: 100 * time.Millisecond},
+			retry:  5,
+			want:   100 * time.Millisecond,
+		},
+		{
+			name:   "quadratic",
+			policy: RetryPolicy{Backoff: BackoffQuadratic, InitialDelay: 100 * time.Millisecond},
+			retry:  3,
+			want:   900 * time.Millisecond,
+		},
+		{
+			name:   "capped at max delay",
+			policy: RetryPolicy{Backoff: BackoffExponential, InitialDelay: time.Second, Multiplier: 2, MaxDelay: 10 * time.Second},
+			retry:  10,
+			want:   10 * time.Second,
+		},
+		{
+			name:   "no max delay",
+			policy: RetryPolicy{Backoff: BackoffLinear, InitialDelay: time.Minute},
+			retry:  5,
+			want:   5 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Delay(tt.retry, errors.New("failed")); got != tt.want {
+				t.Errorf("Delay(%d) = %v, want %v", tt.retry, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDelayJitter(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   RetryPolicy
+		min, max time.Duration
+	}{
+		{
+			name:   "within the jitter fraction",
+			policy: RetryPolicy{Backoff: BackoffFixed, InitialDelay: time.Second, Jitter: 0.2},
+			min:    800 * time.Millisecond,
+			max:    1200 * time.Millisecond,
+		},
+		{
+			name:   "capped at max delay",
+			policy: RetryPolicy{Backoff: BackoffFixed, InitialDelay: time.Second, Jitter: 0.5, MaxDelay: time.Second},
+			min:    500 * time.Millisecond,
+			max:    time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			varied := false
+			first := tt.policy.Delay(1, nil)
+			for i := 0; i < 1000; i++ {
+				got := tt.policy.Delay(1, nil)
+				if got < tt.min || got > tt.max {
+					t.Fatalf("Delay = %v, want between %v and %v", got, tt.min, tt.max)
+				}
+				varied = varied || got != first
+			}
+			if !varied {
+				t.Error("jitter never changed the delay")
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDelayRetryAfter(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxDelay time.Duration
+		wait     time.Duration
+		want     time.Duration
+	}{
+		{name: "within max delay", maxDelay: time.Minute, wait: 30 * time.Second, want: 30 * time.Second},
+		{name: "capped at max delay", maxDelay: time.Minute, wait: time.Hour, want: time.Minute},
+		{name: "no max delay", wait: time.Hour, want: time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := RetryPolicy{Backoff: BackoffFixed, InitialDelay: time.Second, Jitter: 0.5, MaxDelay: tt.maxDelay}
+			err := &core.NodeError{Code: core.ErrCodeRateLimited, HTTPStatus: http.StatusTooManyRequests, Wait: tt.wait}
+			if got := policy.Delay(1, err); got != tt.want {
+				t.Errorf("Delay = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyShouldRetry(t *testing.T) {
+	serverErr := &core.NodeError{Code: core.ErrCodeUpstream, HTTPStatus: http.StatusBadGateway, Retryable: true}
+	rateLimited := &core.NodeError{Code: core.ErrCodeRateLimited, HTTPStatus: http.StatusTooManyRequests, Retryable: true}
+	badRequest := &core.NodeError{Code: core.ErrCodeBadRequest, HTTPStatus: http.StatusBadRequest}
+	netErr := &net.DNSError{Err: "no such host", Name: "example.invalid"}
+
+	tests := []struct {
+		name    string
+		retryOn []string
+		err     error
+		want    bool
+	}{
+		{name: "no rules retry plain errors", err: errors.New("failed"), want: true},
+		{name: "no rules retry retryable node errors", err: serverErr, want: true},
+		{name: "no rules skip non-retryable node errors", err: badRequest, want: false},
+		{name: "any", retryOn: []string{RetryOnAny}, err: badRequest, want: true},
+		{name: "timeout", retryOn: []string{RetryOnTimeout}, err: context.DeadlineExceeded, want: true},
+		{name: "timeout skips others", retryOn: []string{RetryOnTimeout}, err: serverErr, want: false},
+		{name: "network", retryOn: []string{RetryOnNetwork}, err: netErr, want: true},
+		{name: "wrapped network", retryOn: []string{RetryOnNetwork}, err: core.NewRequestError("request failed", netErr), want: true},
+		{name: "network skips others", retryOn: []string{RetryOnNetwork}, err: errors.New("failed"), want: false},
+		{name: "5xx", retryOn: []string{RetryOn5xx}, err: serverErr, want: true},
+		{name: "5xx skips 4xx", retryOn: []string{RetryOn5xx}, err: badRequest, want: false},
+		{name: "429", retryOn: []string{RetryOn429}, err: rateLimited, want: true},
+		{name: "429 skips 5xx", retryOn: []string{RetryOn429}, err: serverErr, want: false},
+		{name: "error code", retryOn: []string{core.ErrCodeBadRequest}, err: badRequest, want: true},
+		{name: "numeric status", retryOn: []string{"400"}, err: badRequest, want: true},
+		{name: "numeric status mismatch", retryOn: []string{"404"}, err: badRequest, want: false},
+		{name: "any rule matching", retryOn: []string{RetryOn429, RetryOn5xx}, err: serverErr, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := RetryPolicy{RetryOn: tt.retryOn}
+			if got := policy.ShouldRetry(tt.err); got != tt.want {
+				t.Errorf("ShouldRetry = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseNodeRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name string
+		node map[string]interface{}
+		want *RetryPolicy
+	}{
+		{
+			name: "legacy retryOnFail",
+			node: map[string]interface{}{"retryOnFail": true, "maxRetries": 2},
+			want: &RetryPolicy{
+				MaxRetries:   2,
+				Backoff:      BackoffQuadratic,
+				InitialDelay: 100 * time.Millisecond,
+				MaxDelay:     time.Minute,
+				RetryOn:      []string{RetryOnAny},
+			},
+		},
+		{
+			name: "retryOnFail without retries",
+			node: map[string]interface{}{"retryOnFail": true},
+		},
+		{
+			name: "maxRetries without retryOnFail",
+			node: map[string]interface{}{"maxRetries": 2},
+		},
+		{
+			name: "retry policy over legacy settings",
+			node: map[string]interface{}{
+				"retryOnFail": true,
+				"maxRetries":  2,
+				"retryPolicy": map[string]interface{}{
+					"maxRetries":   float64(5),
+					"backoff":      BackoffLinear,
+					"initialDelay": float64(250),
+					"maxDelay":     float64(5000),
+					"jitter":       1.5,
+					"retryOn":      []interface{}{RetryOn5xx, "429"},
+				},
+			},
+			want: &RetryPolicy{
+				MaxRetries:   5,
+				Backoff:      BackoffLinear,
+				InitialDelay: 250 * time.Millisecond,
+				MaxDelay:     5 * time.Second,
+				Multiplier:   2,
+				Jitter:       1,
+				RetryOn:      []string{RetryOn5xx, "429"},
+			},
+		},
+		{
+			name: "retry policy defaults",
+			node: map[string]interface{}{"retryPolicy": map[string]interface{}{}},
+			want: DefaultRetryPolicy(3),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.node["id"] = "node"
+			tt.node["type"] = sleepNodeType
+			nodes, err := parseNodes(models.JSONArray{tt.node})
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertRetryPolicy(t, nodes[0].RetryPolicy, tt.want)
+		})
+	}
+}
+
+func assertRetryPolicy(t *testing.T, got, want *RetryPolicy) {
+	t.Helper()
+
+	if got == nil || want == nil {
+		if got != want {
+			t.Fatalf("policy = %+v, want %+v", got, want)
+		}
+		return
+	}
+	if got.MaxRetries != want.MaxRetries || got.Backoff != want.Backoff ||
+		got.InitialDelay != want.InitialDelay || got.MaxDelay != want.MaxDelay ||
+		got.Multiplier != want.Multiplier || got.Jitter != want.Jitter ||
+		len(got.RetryOn) != len(want.RetryOn) {
+		t.Fatalf("policy = %+v, want %+v", got, want)
+	}
+	for i := range got.RetryOn {
+		if got.RetryOn[i] != want.RetryOn[i] {
+			t.Fatalf("retryOn = %v, want %v", got.RetryOn, want.RetryOn)
+		}
+	}
+}
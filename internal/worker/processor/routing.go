@@ -0,0 +1,94 @@
+package processor
+
+import (
+	"github.com/linkflow-ai/linkflow/internal/worker/core"
+)
+
+// defaultHandles are the generic output handles of a node. Connections drawn
+// from them are followed regardless of which branch a router node selected.
+var defaultHandles = map[string]bool{
+	"":       true,
+	"output": true,
+	"main":   true,
+}
+
+// resolveRoutes returns the output handles a node selected for its output,
+// or nil when every outgoing connection should be followed
+func resolveRoutes(handler core.Node, output map[string]interface{}) []string {
+	router, ok := handler.(core.Router)
+	if !ok {
+		return nil
+	}
+	return router.Routes(output)
+}
+
+// SetNodeRoutes records the output handles a node selected
+func (rctx *RuntimeContext) SetNodeRoutes(nodeID string, routes []string) {
+	if routes == nil {
+		return
+	}
+	selected := make(map[string]bool, len(routes))
+	for _, r := range routes {
+		selected[r] = true
+	}
+	rctx.nodeRoutes.Store(nodeID, selected)
+}
+
+// IsInputActive reports whether data flows along an input connection: the
+// source must not have been skipped, and if it is a router the connection's
+// handle must be one it selected
+func (rctx *RuntimeContext) IsInputActive(conn ConnectionRef) bool {
+	result, ok := rctx.GetNodeResult(conn.SourceNodeID)
+	if ok && result.Status == NodeStatusSkipped {
+		return false
+	}
+
+	// A node that routed its error feeds only its error handle, which is
+	// otherwise inactive
+	failed := ok && result.Status == NodeStatusFailed
+	if failed || conn.SourceHandle == ErrorHandle {
+		return failed && conn.SourceHandle == ErrorHandle
+	}
+
+	v, ok := rctx.nodeRoutes.Load(conn.SourceNodeID)
+	if !ok {
+		return true
+	}
+	if defaultHandles[conn.SourceHandle] {
+		return true
+	}
+	return v.(map[string]bool)[conn.SourceHandle]
+}
+
+// ActiveInputs returns the input connections of a node that carry data
+func (rctx *RuntimeContext) ActiveInputs(node *NodeDefinition) []ConnectionRef {
+	active := make([]ConnectionRef, 0, len(node.Inputs))
+	for _, conn := range node.Inputs {
+		if rctx.IsInputActive(conn) {
+			active = append(active, conn)
+		}
+	}
+	return active
+}
+
+// shouldExecute reports whether a node is reachable along the branches taken
+// so far. Root nodes always run; any other node runs when at least one of its
+// inputs is active, so skips propagate through nodes whose inputs were all skipped.
+func shouldExecute(rctx *RuntimeContext, node *NodeDefinition) bool {
+	if len(node.Inputs) == 0 {
+		return true
+	}
+	return len(rctx.ActiveInputs(node)) > 0
+}
+
+// markSkipped records a node as skipped on an untaken branch
+func (p *Processor) markSkipped(rctx *RuntimeContext, node *NodeDefinition) {
+	rctx.SetNodeResult(&NodeResult{
+		NodeID:   node.ID,
+		NodeType: node.Type,
+		NodeName: node.Name,
+		Status:   NodeStatusSkipped,
+	})
+	rctx.PublishNodeSkipped(node)
+	p.recordNode(rctx, node.ID)
+}
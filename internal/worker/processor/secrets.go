@@ -0,0 +1,306 @@
+package processor
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/linkflow-ai/linkflow/internal/domain/models"
+	"github.com/linkflow-ai/linkflow/internal/worker/core"
+	"github.com/rs/zerolog/log"
+)
+
+// MaskedValue replaces secret values in what an execution stores, publishes
+// and logs
+const MaskedValue = "********"
+
+// minSecretLength keeps very short values, which would match all over the
+// place, from being redacted
+const minSecretLength = 4
+
+// EnvVar is a workspace environment variable exposed to expressions as $env
+type EnvVar struct {
+	Value string
+	// Secret values are redacted like credentials
+	Secret bool
+}
+
+// redactor tracks the secret values resolved for an execution and scrubs them
+// from values before they leave the processor. Secrets are also matched in
+// their base64 and URL-encoded forms, anywhere inside a string.
+type redactor struct {
+	mu       sync.RWMutex
+	secrets  map[string]bool
+	replacer *strings.Replacer
+}
+
+// add registers secret values along with their encoded forms
+func (r *redactor) add(values ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	added := false
+	for _, v := range values {
+		if len(v) < minSecretLength {
+			continue
+		}
+		for _, form := range encodedForms(v) {
+			if r.secrets[form] {
+				continue
+			}
+			if r.secrets == nil {
+				r.secrets = make(map[string]bool)
+			}
+			r.secrets[form] = true
+			added = true
+		}
+	}
+	if !added {
+		return
+	}
+
+	// Longer secrets first, so a secret containing another is redacted whole
+	sorted := make([]string, 0, len(r.secrets))
+	for v := range r.secrets {
+		sorted = append(sorted, v)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+
+	pairs := make([]string, 0, 2*len(sorted))
+	for _, v := range sorted {
+		pairs = append(pairs, v, MaskedValue)
+	}
+	r.replacer = strings.NewReplacer(pairs...)
+}
+
+// encodedForms returns a secret as is, base64 encoded and URL encoded. The
+// unpadded base64 forms also match the padded ones.
+func encodedForms(v string) []string {
+	forms := []string{
+		v,
+		base64.RawStdEncoding.EncodeToString([]byte(v)),
+		base64.RawURLEncoding.EncodeToString([]byte(v)),
+	}
+	if escaped := url.QueryEscape(v); escaped != v {
+		forms = append(forms, escaped)
+	}
+	return forms
+}
+
+// addCredential registers the secret fields of a credential. Basic auth
+// credentials are also matched as the base64 "user:password" of a header.
+func (r *redactor) addCredential(data *models.CredentialData) {
+	if data == nil {
+		return
+	}
+
+	values := []string{
+		data.APIKey,
+		data.ClientSecret,
+		data.AccessToken,
+		data.RefreshToken,
+		data.Password,
+		data.Token,
+		data.ConnectionString,
+	}
+	if data.Username != "" && data.Password != "" {
+		values = append(values, data.Username+":"+data.Password)
+	}
+	for _, v := range data.Custom {
+		values = append(values, v)
+	}
+	for _, v := range data.Data {
+		if s, ok := v.(string); ok {
+			values = append(values, s)
+		}
+	}
+	r.add(values...)
+}
+
+func (r *redactor) active() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.replacer != nil
+}
+
+func (r *redactor) redactString(s string) string {
+	r.mu.RLock()
+	replacer := r.replacer
+	r.mu.RUnlock()
+
+	if replacer == nil {
+		return s
+	}
+	return replacer.Replace(s)
+}
+
+// redact returns a copy of v with the secrets redacted in all strings
+func (r *redactor) redact(v interface{}) interface{} {
+	switch val := v.(type) {
+	case nil, bool, int, int64, float64:
+		return v
+	case string:
+		return r.redactString(val)
+	case map[string]interface{}:
+		return r.redactMap(val)
+	case []interface{}:
+		redacted := make([]interface{}, len(val))
+		for i, item := range val {
+			redacted[i] = r.redact(item)
+		}
+		return redacted
+	case map[string]string:
+		redacted := make(map[string]string, len(val))
+		for k, item := range val {
+			redacted[k] = r.redactString(item)
+		}
+		return redacted
+	case []string:
+		redacted := make([]string, len(val))
+		for i, item := range val {
+			redacted[i] = r.redactString(item)
+		}
+		return redacted
+	default:
+		// Other types are redacted in their JSON form
+		data, err := json.Marshal(val)
+		if err != nil {
+			return v
+		}
+		var redacted interface{}
+		if err := json.Unmarshal([]byte(r.redactString(string(data))), &redacted); err != nil {
+			return MaskedValue
+		}
+		return redacted
+	}
+}
+
+func (r *redactor) redactMap(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	redacted := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		redacted[k] = r.redact(v)
+	}
+	return redacted
+}
+
+// setEnv exposes the workspace's environment variables and registers the
+// secret ones for redaction
+func (rctx *RuntimeContext) setEnv(env map[string]EnvVar) {
+	rctx.env = make(map[string]string, len(env))
+	var secrets []string
+	for name, v := range env {
+		rctx.env[name] = v.Value
+		if v.Secret {
+			secrets = append(secrets, v.Value)
+		}
+	}
+	rctx.redactor.add(secrets...)
+}
+
+// trackCredentials registers every credential an execution resolves for
+// redaction
+func (rctx *RuntimeContext) trackCredentials(getCredential func(uuid.UUID) (*models.CredentialData, error)) func(uuid.UUID) (*models.CredentialData, error) {
+	if getCredential == nil {
+		return nil
+	}
+	return func(id uuid.UUID) (*models.CredentialData, error) {
+		data, err := getCredential(id)
+		if err == nil {
+			rctx.redactor.addCredential(data)
+		}
+		return data, err
+	}
+}
+
+// trackRestoredCredentials registers the credentials of the nodes restored
+// from a saved state, whose outputs may hold them, for redaction. They were
+// resolved by an earlier run and are resolved again here.
+func (rctx *RuntimeContext) trackRestoredCredentials(state *ExecutionState) {
+	if rctx.GetCredential == nil {
+		return
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	for nodeID := range state.NodeResults {
+		node, ok := rctx.nodes[nodeID]
+		if !ok {
+			continue
+		}
+		id, err := uuid.Parse(core.GetString(node.Config, "credentialId", ""))
+		if err != nil || seen[id] {
+			continue
+		}
+		seen[id] = true
+		if _, err := rctx.GetCredential(id); err != nil {
+			log.Warn().Err(err).
+				Str("execution_id", rctx.ExecutionID.String()).
+				Str("node_id", nodeID).
+				Msg("Failed to resolve credential of restored node for redaction")
+		}
+	}
+}
+
+// Redact returns a copy of v without the execution's secrets, for anything
+// that is stored, published or logged
+func (rctx *RuntimeContext) Redact(v interface{}) interface{} {
+	if !rctx.redactor.active() {
+		return v
+	}
+	return rctx.redactor.redact(v)
+}
+
+// RedactString returns s without the execution's secrets
+func (rctx *RuntimeContext) RedactString(s string) string {
+	return rctx.redactor.redactString(s)
+}
+
+// redactNodeResult returns a copy of a node result that is safe to store
+func (rctx *RuntimeContext) redactNodeResult(result *NodeResult) *NodeResult {
+	if !rctx.redactor.active() {
+		return result
+	}
+
+	redacted := *result
+	redacted.Input = rctx.redactor.redactMap(result.Input)
+	redacted.Output = rctx.redactor.redactMap(result.Output)
+	redacted.Error = rctx.redactor.redactString(result.Error)
+	redacted.ErrorDetails = rctx.redactNodeError(result.ErrorDetails)
+	return &redacted
+}
+
+// redactNodeError returns a copy of a node error that is safe to store. The
+// wrapped error's message is folded into Message.
+func (rctx *RuntimeContext) redactNodeError(err *core.NodeError) *core.NodeError {
+	if err == nil || !rctx.redactor.active() {
+		return err
+	}
+
+	redacted := *err
+	redacted.Message = rctx.redactor.redactString(err.Error())
+	redacted.Err = nil
+	redacted.Response = rctx.redactor.redactString(err.Response)
+	redacted.Hint = rctx.redactor.redactString(err.Hint)
+	return &redacted
+}
+
+// redactResult redacts the outputs, node results and error of an execution
+// result
+func (rctx *RuntimeContext) redactResult(result *Result) {
+	if !rctx.redactor.active() {
+		return
+	}
+
+	result.Output = rctx.redactor.redactMap(result.Output)
+	for nodeID, nodeResult := range result.NodeResults {
+		result.NodeResults[nodeID] = rctx.redactNodeResult(nodeResult)
+	}
+	result.Error = rctx.redactor.redactString(result.Error)
+	result.ErrorDetails = rctx.redactNodeError(result.ErrorDetails)
+}
@@ -0,0 +1,167 @@
+package processor
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/linkflow-ai/linkflow/internal/domain/models"
+	"github.com/linkflow-ai/linkflow/internal/worker/core"
+)
+
+const testSecret = "s3cr3t/t0ken+value"
+
+// assertNoSecret fails when s holds the secret in any form it's redacted in
+func assertNoSecret(t *testing.T, s string) {
+	t.Helper()
+
+	for _, form := range encodedForms(testSecret) {
+		if strings.Contains(s, form) {
+			t.Fatalf("%q still holds the secret as %q", s, form)
+		}
+	}
+	if !strings.Contains(s, MaskedValue) {
+		t.Fatalf("%q has no masked value", s)
+	}
+}
+
+func newRedactor(secrets ...string) *redactor {
+	r := &redactor{}
+	r.add(secrets...)
+	return r
+}
+
+func TestRedactStringForms(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "as is", input: testSecret},
+		{name: "substring", input: "Authorization: Bearer " + testSecret + " (expires soon)"},
+		{name: "base64", input: "Basic " + base64.StdEncoding.EncodeToString([]byte(testSecret))},
+		{name: "unpadded base64", input: base64.RawStdEncoding.EncodeToString([]byte(testSecret))},
+		{name: "URL-safe base64", input: "token=" + base64.URLEncoding.EncodeToString([]byte(testSecret))},
+		{name: "URL-encoded", input: "https://api.example.com/v1?key=" + url.QueryEscape(testSecret) + "&page=2"},
+	}
+
+	r := newRedactor(testSecret)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertNoSecret(t, r.redactString(tt.input))
+		})
+	}
+}
+
+func TestRedactKeepsShortAndUnrelatedValues(t *testing.T) {
+	r := newRedactor("abc", testSecret)
+
+	if got := r.redactString("abc and more"); got != "abc and more" {
+		t.Errorf("short value redacted: %q", got)
+	}
+	if got := r.redactString("nothing secret"); got != "nothing secret" {
+		t.Errorf("unrelated value changed: %q", got)
+	}
+}
+
+func TestRedactLongestSecretFirst(t *testing.T) {
+	r := newRedactor("abcd", "abcdefgh")
+
+	if got := r.redactString("key abcdefgh"); got != "key "+MaskedValue {
+		t.Errorf("got %q, want the longer secret redacted whole", got)
+	}
+}
+
+func TestRedactNestedValues(t *testing.T) {
+	r := newRedactor(testSecret)
+	input := map[string]interface{}{
+		"headers": map[string]interface{}{
+			"Authorization": "Bearer " + testSecret,
+		},
+		"items": []interface{}{
+			map[string]interface{}{"token": testSecret, "count": float64(2)},
+			"plain",
+		},
+		"strings": []string{"x", testSecret},
+		"labels":  map[string]string{"key": testSecret},
+	}
+
+	redacted := r.redact(input).(map[string]interface{})
+
+	assertNoSecret(t, redacted["headers"].(map[string]interface{})["Authorization"].(string))
+	items := redacted["items"].([]interface{})
+	item := items[0].(map[string]interface{})
+	assertNoSecret(t, item["token"].(string))
+	if item["count"] != float64(2) || items[1] != "plain" {
+		t.Errorf("non-secret values changed: %v", items)
+	}
+	assertNoSecret(t, redacted["strings"].([]string)[1])
+	assertNoSecret(t, redacted["labels"].(map[string]string)["key"])
+
+	// The input is left alone
+	if input["headers"].(map[string]interface{})["Authorization"] != "Bearer "+testSecret {
+		t.Error("redact modified its input")
+	}
+}
+
+func TestRedactNodeResultErrors(t *testing.T) {
+	rctx := NewRuntimeContext(context.Background(), uuid.New(), uuid.New(), uuid.New(), Input{}, nil, nil)
+	rctx.redactor.add(testSecret)
+
+	result := rctx.redactNodeResult(&NodeResult{
+		NodeID: "http",
+		Error:  "request to ?key=" + url.QueryEscape(testSecret) + " failed",
+		ErrorDetails: &core.NodeError{
+			Code:     core.ErrCodeCredential,
+			Message:  "unauthorized",
+			Response: `{"error":"invalid key ` + testSecret + `"}`,
+			Hint:     "check key " + testSecret,
+			Err:      errors.New("token " + testSecret + " rejected"),
+		},
+	})
+
+	assertNoSecret(t, result.Error)
+	assertNoSecret(t, result.ErrorDetails.Message)
+	assertNoSecret(t, result.ErrorDetails.Response)
+	assertNoSecret(t, result.ErrorDetails.Hint)
+	if result.ErrorDetails.Err != nil {
+		t.Error("wrapped error kept, its message may hold the secret")
+	}
+}
+
+func TestSetEnvRedactsSecretValues(t *testing.T) {
+	rctx := NewRuntimeContext(context.Background(), uuid.New(), uuid.New(), uuid.New(), Input{}, nil, nil)
+	rctx.setEnv(map[string]EnvVar{
+		"API_KEY": {Value: testSecret, Secret: true},
+		"REGION":  {Value: "eu-west-1"},
+	})
+
+	if rctx.env["API_KEY"] != testSecret {
+		t.Errorf("$env.API_KEY = %q, want the raw value", rctx.env["API_KEY"])
+	}
+
+	redacted := rctx.Redact(map[string]interface{}{
+		"key":    "key=" + testSecret,
+		"region": "eu-west-1",
+	}).(map[string]interface{})
+	assertNoSecret(t, redacted["key"].(string))
+	if redacted["region"] != "eu-west-1" {
+		t.Errorf("non-secret variable redacted: %v", redacted["region"])
+	}
+}
+
+func TestAddCredentialRedactsBasicAuth(t *testing.T) {
+	r := &redactor{}
+	r.addCredential(&models.CredentialData{Username: "admin", Password: "hunter2!"})
+
+	header := "Basic " + base64.StdEncoding.EncodeToString([]byte("admin:hunter2!"))
+	if got := r.redactString(header); strings.Contains(got, base64.RawStdEncoding.EncodeToString([]byte("admin:hunter2!"))) {
+		t.Errorf("basic auth header not redacted: %q", got)
+	}
+	if got := r.redactString("password hunter2!"); got != "password "+MaskedValue {
+		t.Errorf("password not redacted: %q", got)
+	}
+}
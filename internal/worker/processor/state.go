@@ -0,0 +1,188 @@
+package processor
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/linkflow-ai/linkflow/internal/worker/core"
+)
+
+// ExecutionState is the serializable state of a suspended execution: the
+// data it was started with and everything the nodes that already ran
+// produced. Nodes with a result are not executed again on resume, so the
+// results also mark the execution's position in the DAG.
+type ExecutionState struct {
+	Input         map[string]interface{} `json:"input"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+	NodeOutputs   map[string]interface{} `json:"nodeOutputs"`
+	NodeResults   map[string]*NodeResult `json:"nodeResults"`
+	NodeRoutes    map[string][]string    `json:"nodeRoutes,omitempty"`
+	ItemRoutes    map[string][][]string  `json:"itemRoutes,omitempty"`
+	WaitingNodeID string                 `json:"waitingNodeId"`
+	Suspension    *core.Suspension       `json:"suspension"`
+}
+
+// ResumeOptions continue a suspended execution
+type ResumeOptions struct {
+	State *ExecutionState
+	// Data is passed by the resume call and added to the waiting node's output
+	Data map[string]interface{}
+}
+
+// ToMap converts the state for storage in a JSON column
+func (s *ExecutionState) ToMap() (map[string]interface{}, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize execution state: %w", err)
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to serialize execution state: %w", err)
+	}
+	return m, nil
+}
+
+// ExecutionStateFromMap restores a state stored with ToMap
+func ExecutionStateFromMap(m map[string]interface{}) (*ExecutionState, error) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read execution state: %w", err)
+	}
+	var state ExecutionState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to read execution state: %w", err)
+	}
+	if state.WaitingNodeID == "" || state.Suspension == nil {
+		return nil, fmt.Errorf("execution state has no waiting node")
+	}
+	return &state, nil
+}
+
+// suspend records that a node parked the execution. Only the first
+// suspension counts; parallel nodes of the same level finish normally.
+func (rctx *RuntimeContext) suspend(nodeID string, s *core.Suspension) {
+	rctx.mu.Lock()
+	defer rctx.mu.Unlock()
+	if rctx.suspension == nil {
+		rctx.suspension = s
+		rctx.suspendedNode = nodeID
+	}
+}
+
+// Suspension returns the suspension requested during the execution, if any
+func (rctx *RuntimeContext) Suspension() (*core.Suspension, string) {
+	rctx.mu.RLock()
+	defer rctx.mu.RUnlock()
+	return rctx.suspension, rctx.suspendedNode
+}
+
+// snapshot captures the state of a suspended execution
+func (rctx *RuntimeContext) snapshot() *ExecutionState {
+	suspension, nodeID := rctx.Suspension()
+
+	state := &ExecutionState{
+		Input:         rctx.Input,
+		NodeOutputs:   rctx.GetAllNodeOutputs(),
+		NodeResults:   rctx.GetAllNodeResults(),
+		NodeRoutes:    make(map[string][]string),
+		ItemRoutes:    make(map[string][][]string),
+		WaitingNodeID: nodeID,
+		Suspension:    suspension,
+	}
+
+	rctx.mu.RLock()
+	state.Variables = core.CopyMap(rctx.Variables)
+	rctx.mu.RUnlock()
+
+	rctx.nodeRoutes.Range(func(key, value interface{}) bool {
+		routes := make([]string, 0)
+		for handle := range value.(map[string]bool) {
+			routes = append(routes, handle)
+		}
+		state.NodeRoutes[key.(string)] = routes
+		return true
+	})
+	rctx.itemRoutes.Range(func(key, value interface{}) bool {
+		state.ItemRoutes[key.(string)] = value.([][]string)
+		return true
+	})
+
+	return state
+}
+
+// restoreState loads a suspended execution's state and completes the node it
+// was waiting on with the resume data
+func (p *Processor) restoreState(rctx *RuntimeContext, dag *DAG, resume *ResumeOptions) {
+	state := resume.State
+	restoreProgress(rctx, state)
+
+	node := dag.GetNode(state.WaitingNodeID)
+	if node == nil {
+		return
+	}
+
+	output := core.CopyMap(state.Suspension.Output)
+	if output == nil {
+		output = make(map[string]interface{})
+	}
+
+	handler := core.Get(node.Type)
+	if resumer, ok := handler.(core.Resumer); ok {
+		output = resumer.Resume(output, resume.Data)
+	} else if len(resume.Data) > 0 {
+		output["resumeData"] = resume.Data
+	}
+
+	startedAt := time.Now()
+	if waiting, ok := state.NodeResults[node.ID]; ok {
+		startedAt = waiting.StartedAt
+	}
+
+	if handler != nil {
+		rctx.SetNodeRoutes(node.ID, resolveRoutes(handler, output))
+	}
+	rctx.SetNodeOutput(node.ID, output)
+	rctx.SetNodeResult(&NodeResult{
+		NodeID:      node.ID,
+		NodeType:    node.Type,
+		NodeName:    node.Name,
+		Status:      NodeStatusCompleted,
+		Output:      output,
+		StartedAt:   startedAt,
+		CompletedAt: time.Now(),
+		Duration:    time.Since(startedAt),
+	})
+	rctx.finished[node.ID] = true
+	p.recordNode(rctx, node.ID)
+	rctx.PublishNodeCompleted(node, int(time.Since(startedAt).Milliseconds()), output)
+}
+
+// restoreProgress loads the input, variables and finished nodes of a saved
+// state. The node a suspended execution waits on is left to the caller.
+func restoreProgress(rctx *RuntimeContext, state *ExecutionState) {
+	rctx.trackRestoredCredentials(state)
+
+	rctx.Input = state.Input
+	for k, v := range state.Variables {
+		rctx.SetVariable(k, v)
+	}
+
+	for nodeID, result := range state.NodeResults {
+		if nodeID == state.WaitingNodeID {
+			continue
+		}
+		if output, ok := state.NodeOutputs[nodeID]; ok {
+			rctx.SetNodeOutput(nodeID, output)
+		}
+		rctx.SetNodeResult(result)
+		rctx.finished[nodeID] = true
+		rctx.restored[nodeID] = true
+	}
+	for nodeID, routes := range state.NodeRoutes {
+		rctx.SetNodeRoutes(nodeID, routes)
+	}
+	for nodeID, routes := range state.ItemRoutes {
+		rctx.itemRoutes.Store(nodeID, routes)
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/linkflow-ai/linkflow/internal/domain/models"
+	"github.com/linkflow-ai/linkflow/internal/worker/core"
 )
 
 // WorkflowDefinition represents a parsed workflow ready for execution
@@ -30,6 +31,12 @@ type NodeDefinition struct {
 	RetryOnFail bool
 	MaxRetries  int
 	Timeout     time.Duration
+	// ExecutionMode is core.ModeOnceForAllItems (default) or core.ModeOnceForEachItem
+	ExecutionMode string
+	// OnError is OnErrorStop (default), OnErrorContinue or OnErrorRoute
+	OnError string
+	// RetryPolicy is nil when the node uses the workflow's policy
+	RetryPolicy *RetryPolicy
 }
 
 // Position represents node position in the editor
@@ -61,8 +68,27 @@ type WorkflowSettings struct {
 	SaveExecutionData  bool
 	SaveSuccessfulData bool
 	ExecutionTimeout   time.Duration
+	RetryPolicy        *RetryPolicy
+	// MaxConcurrency limits how many executions of the workflow run at once;
+	// 0 means no limit and 1 runs the workflow as a singleton
+	MaxConcurrency int
+	// OverflowPolicy decides what happens to executions over MaxConcurrency
+	OverflowPolicy string
+	// Environment (dev, staging or prod) selects the environment variables
+	// exposed as $env; the worker's environment is used when it's empty
+	Environment string
 }
 
+// Overflow policies for executions over a workflow's MaxConcurrency
+const (
+	// OverflowQueue runs the execution later, when a slot is free
+	OverflowQueue = "queue"
+	// OverflowDrop cancels the execution
+	OverflowDrop = "drop"
+	// OverflowCancelOldest cancels the oldest running execution instead
+	OverflowCancelOldest = "cancelOldest"
+)
+
 // Input represents workflow input data
 type Input map[string]interface{}
 
@@ -78,22 +104,30 @@ type Result struct {
 	NodesExecuted  int
 	Error          string
 	ErrorNodeID    string
+	ErrorDetails   *core.NodeError
+	// TimedOut is set when the execution failed by exceeding WorkflowTimeout
+	TimedOut bool
+	// State is set for waiting and paused executions and resumes them later
+	State *ExecutionState
 }
 
 // NodeResult represents a single node execution result
 type NodeResult struct {
-	NodeID      string
-	NodeType    string
-	NodeName    string
-	Status      NodeStatus
-	Input       map[string]interface{}
-	Output      map[string]interface{}
-	Error       string
-	StartedAt   time.Time
-	CompletedAt time.Time
-	Duration    time.Duration
-	Retries     int
-	Cached      bool
+	NodeID   string
+	NodeType string
+	NodeName string
+	Status   NodeStatus
+	Input    map[string]interface{}
+	Output   map[string]interface{}
+	Error    string
+	// ErrorDetails classifies Error for the user and the retry policy
+	ErrorDetails *core.NodeError
+	StartedAt    time.Time
+	CompletedAt  time.Time
+	Duration     time.Duration
+	Retries      int
+	Cached       bool
+	Pinned       bool
 }
 
 // PreviewResult represents a dry-run preview result
@@ -111,6 +145,8 @@ type NodePreview struct {
 	WouldExecute  bool
 	ResolvedInput map[string]interface{}
 	DependsOn     []string
+	// SideEffects reports whether a dry run would simulate the node
+	SideEffects bool
 }
 
 // ValidationError represents a workflow validation error
@@ -139,6 +175,8 @@ const (
 	StatusFailed    ExecutionStatus = "failed"
 	StatusCancelled ExecutionStatus = "cancelled"
 	StatusTimedOut  ExecutionStatus = "timed_out"
+	StatusWaiting   ExecutionStatus = "waiting"
+	StatusPaused    ExecutionStatus = "paused"
 )
 
 // NodeStatus represents node execution status
@@ -151,6 +189,7 @@ const (
 	NodeStatusFailed    NodeStatus = "failed"
 	NodeStatusSkipped   NodeStatus = "skipped"
 	NodeStatusCached    NodeStatus = "cached"
+	NodeStatusWaiting   NodeStatus = "waiting"
 )
 
 // ExecutionOptions configures how a workflow is executed
@@ -164,6 +203,26 @@ type ExecutionOptions struct {
 	StopAtNode         string
 	SkipNodes          []string
 	NodeOverrides      map[string]map[string]interface{}
+	// SeedOutputs holds node outputs from a previous execution. Nodes outside a
+	// StartFromNode/StopAtNode run reuse them instead of executing again. A
+	// nil output marks a node that ran but whose output wasn't saved.
+	SeedOutputs map[string]map[string]interface{}
+	// PinnedData holds pinned node outputs used instead of running those nodes
+	PinnedData map[string]map[string]interface{}
+	// Resume continues a suspended execution from its saved state
+	Resume *ResumeOptions
+	// Recover continues an execution interrupted by a worker crash from its
+	// last checkpoint
+	Recover bool
+	// Paused continues a paused execution from the state it paused with
+	Paused *ExecutionState
+	// Pause stops the execution before its next node when it's requested
+	Pause *PauseSignal
+	// RetryPolicy applies to nodes without their own; it defaults to the
+	// workflow's settings
+	RetryPolicy *RetryPolicy
+	// Env holds the workspace's environment variables, exposed as $env
+	Env map[string]EnvVar
 }
 
 // DefaultExecutionOptions returns sensible defaults
@@ -212,10 +271,8 @@ func ParseWorkflow(workflow *models.Workflow) (*WorkflowDefinition, error) {
 	}
 	def.Connections = connections
 
-	// Parse settings
-	if workflow.Settings != nil {
-		def.Settings = parseSettings(workflow.Settings)
-	}
+	// Parse settings; workflows without any get the defaults
+	def.Settings = parseSettings(workflow.Settings)
 
 	return def, nil
 }
@@ -256,11 +313,22 @@ func parseNodes(data models.JSONArray) ([]*NodeDefinition, error) {
 		node.Disabled = getBool(nodeMap, "disabled", false)
 		node.RetryOnFail = getBool(nodeMap, "retryOnFail", false)
 		node.MaxRetries = getInt(nodeMap, "maxRetries", 0)
+		node.ExecutionMode = getString(nodeMap, "executionMode", core.ModeOnceForAllItems)
+		node.OnError = getString(nodeMap, "onError", OnErrorStop)
+		if getBool(nodeMap, "continueOnFail", false) && node.OnError == OnErrorStop {
+			node.OnError = OnErrorContinue
+		}
 
 		if timeout := getInt(nodeMap, "timeout", 0); timeout > 0 {
 			node.Timeout = time.Duration(timeout) * time.Millisecond
 		}
 
+		if policy, ok := nodeMap["retryPolicy"].(map[string]interface{}); ok {
+			node.RetryPolicy = parseRetryPolicy(policy)
+		} else if node.RetryOnFail && node.MaxRetries > 0 {
+			node.RetryPolicy = LegacyRetryPolicy(node.MaxRetries)
+		}
+
 		nodes = append(nodes, node)
 	}
 
@@ -302,7 +370,20 @@ func parseSettings(data models.JSON) WorkflowSettings {
 		CallerPolicy:       getString(data, "callerPolicy", "workflowsFromSameOwner"),
 		SaveExecutionData:  getBool(data, "saveExecutionData", true),
 		SaveSuccessfulData: getBool(data, "saveSuccessfulData", true),
+		ExecutionTimeout:   time.Duration(getInt(data, "executionTimeout", 0)) * time.Second,
+		MaxConcurrency:     getInt(data, "maxConcurrency", 0),
+		OverflowPolicy:     getString(data, "overflowPolicy", OverflowQueue),
+		Environment:        getString(data, "environment", ""),
+		RetryPolicy:        workflowRetryPolicy(data),
+	}
+}
+
+// workflowRetryPolicy reads the default retry policy of a workflow's nodes
+func workflowRetryPolicy(data models.JSON) *RetryPolicy {
+	if policy, ok := data["retryPolicy"].(map[string]interface{}); ok {
+		return parseRetryPolicy(policy)
 	}
+	return nil
 }
 
 func getString(m map[string]interface{}, key, defaultVal string) string {
@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
 	"github.com/linkflow-ai/linkflow/internal/domain/services"
 	"github.com/linkflow-ai/linkflow/internal/pkg/config"
@@ -33,7 +35,11 @@ type Worker struct {
 	emailSvc     *email.Service
 	publisher    *events.Publisher
 	metrics      *middleware.MetricsCollector
+	recorder     *executor.NodeExecutionRecorder
+	checkpointer *executor.RedisCheckpointer
 	redisClient  *redis.Client
+	fairQueue    *queue.FairQueue
+	stopFeeding  context.CancelFunc
 }
 
 // Dependencies holds all external dependencies for the worker
@@ -65,10 +71,32 @@ func New(
 	// Create queue client for node dependencies
 	queueClient := queue.NewClient(&cfg.Redis)
 
+	// Feed executions staged per workspace to the queues, weighted by plan
+	var fairQueue *queue.FairQueue
+	if cfg.Features.FairQueue.Enabled {
+		fairQueue = queue.NewFairQueue(redisClient, queueClient)
+		if billingSvc != nil {
+			fairQueue.SetWeightFunc(func(ctx context.Context, workspaceID uuid.UUID) int {
+				limits, err := billingSvc.GetPlanLimits(ctx, workspaceID)
+				if err != nil {
+					return 1
+				}
+				return limits.QueueWeight
+			})
+		}
+		queueClient.SetFairQueue(fairQueue)
+	}
+
 	// Set global dependencies for nodes that need them
+	baseURL := cfg.App.URL
+	if baseURL == "" {
+		baseURL = fmt.Sprintf("http://localhost:%d", cfg.Server.Port)
+	}
 	nodes.SetGlobalDependencies(&nodes.Dependencies{
-		QueueClient: queueClient,
-		RedisClient: redisClient,
+		QueueClient:  queueClient,
+		RedisClient:  redisClient,
+		EmailService: emailSvc,
+		BaseURL:      baseURL,
 	})
 
 	// Create middleware chain
@@ -97,11 +125,20 @@ func New(
 	// Create metrics collector
 	metricsCollector := middleware.NewMetricsCollector()
 
+	// Create node execution recorder
+	recorder := executor.NewNodeExecutionRecorder(executionSvc, executor.DefaultRecorderConfig())
+
+	// Create execution checkpoint store
+	checkpointer := executor.NewRedisCheckpointer(redisClient, 24*time.Hour)
+
 	// Create processor
 	proc := processor.New(processor.Config{
-		Middleware: middlewareChain,
-		Cache:      resultCache,
-		Metrics:    metricsCollector,
+		Middleware:         middlewareChain,
+		Cache:              resultCache,
+		Metrics:            metricsCollector,
+		Recorder:           recorder,
+		Checkpointer:       checkpointer,
+		CheckpointInterval: processor.DefaultCheckpointInterval,
 	})
 
 	// Create cancellation manager
@@ -126,6 +163,13 @@ func New(
 		redisClient,
 	)
 
+	// Failed executions trigger their workflow's error workflow
+	appURL := cfg.App.FrontendURL
+	if appURL == "" {
+		appURL = baseURL
+	}
+	exec.SetErrorWorkflowQueue(queueClient, appURL)
+
 	w := &Worker{
 		cfg:          cfg,
 		server:       server,
@@ -135,7 +179,10 @@ func New(
 		emailSvc:     emailSvc,
 		publisher:    publisher,
 		metrics:      metricsCollector,
+		recorder:     recorder,
+		checkpointer: checkpointer,
 		redisClient:  redisClient,
+		fairQueue:    fairQueue,
 	}
 
 	// Register handlers
@@ -155,6 +202,16 @@ func (w *Worker) Start() error {
 	// Start cancellation listener
 	go w.cancellation.Listen(context.Background())
 
+	// Start node execution recorder
+	w.recorder.Start()
+
+	// Start feeding staged executions
+	if w.fairQueue != nil {
+		feedCtx, stop := context.WithCancel(context.Background())
+		w.stopFeeding = stop
+		go w.fairQueue.Run(feedCtx, w.cfg.Features.FairQueue.Backlog)
+	}
+
 	// Start credential cache cleanup
 	ctx := context.Background()
 	go func() {
@@ -176,7 +233,18 @@ func (w *Worker) Start() error {
 // Shutdown gracefully shuts down the worker
 func (w *Worker) Shutdown() {
 	log.Info().Msg("Shutting down worker...")
+	if w.stopFeeding != nil {
+		w.stopFeeding()
+	}
 	w.server.Shutdown()
+	w.recorder.Stop()
+}
+
+// SetStateEncryptor encrypts the execution state the worker stores between
+// runs: checkpoints and the state of paused and waiting executions
+func (w *Worker) SetStateEncryptor(encryptor services.Encryptor) {
+	w.checkpointer.SetEncryptor(encryptor)
+	w.executor.SetStateEncryptor(encryptor)
 }
 
 // GetExecutor returns the executor for API access
@@ -202,6 +270,9 @@ func (w *Worker) handleWorkflowExecution(ctx context.Context, task *asynq.Task)
 		Str("workspace_id", payload.WorkspaceID.String()).
 		Msg("Processing workflow execution")
 
+	// Re-deliveries of the task continue the execution it created
+	payload.TaskID, _ = asynq.GetTaskID(ctx)
+
 	return w.executor.Execute(context.Background(), payload)
 }
 